@@ -0,0 +1,254 @@
+package list
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"charm.land/bubbles/v2/key"
+)
+
+// Action is something the user can do with an item, such as opening, editing
+// or deleting it. The user picks actions from a menu opened with
+// KeyMap.ShowActions, or with the action's own keybinding.
+type Action struct {
+	// Name describes the action in the menu, and identifies it in
+	// ActionMsg.
+	Name string
+
+	// Key optionally triggers the action directly while browsing, and from
+	// the menu. While browsing, it takes precedence over the list's own
+	// keybindings. Its help is shown in the full help.
+	Key key.Binding
+}
+
+// ActionItem is an item with actions of its own.
+type ActionItem interface {
+	Item
+
+	// Actions returns the actions available for the item.
+	Actions() []Action
+}
+
+// ActionDelegate is an ItemDelegate that provides actions for items. Its
+// actions are offered after those of an ActionItem.
+type ActionDelegate interface {
+	ItemDelegate
+
+	// Actions returns the actions available for the given item.
+	Actions(item Item) []Action
+}
+
+// ActionMsg is sent when the user chooses an action for an item.
+type ActionMsg struct {
+	Action Action
+
+	// Index is the index of the item in the unfiltered list of items.
+	Index int
+	Item  Item
+}
+
+// ItemActions returns the actions available for the given item.
+func (m Model) ItemActions(item Item) []Action {
+	if item == nil {
+		return nil
+	}
+	var actions []Action
+	if i, ok := item.(ActionItem); ok {
+		actions = append(actions, i.Actions()...)
+	}
+	if d, ok := m.delegate.(ActionDelegate); ok {
+		actions = append(actions, d.Actions(item)...)
+	}
+	return actions
+}
+
+// hasActions returns whether any items may have actions.
+func (m Model) hasActions() bool {
+	if _, ok := m.delegate.(ActionDelegate); ok {
+		return true
+	}
+	return m.actionable
+}
+
+// anyActionItem returns whether any of the given items are ActionItems.
+func anyActionItem(items []Item) bool {
+	for _, item := range items {
+		if _, ok := item.(ActionItem); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ShowActions opens the action menu for the selected item, if it has any
+// actions.
+func (m *Model) ShowActions() {
+	m.actions = m.ItemActions(m.SelectedItem())
+	m.actionCursor = 0
+	m.actionMenu = len(m.actions) > 0
+	m.updateKeybindings()
+}
+
+// CloseActions closes the action menu.
+func (m *Model) CloseActions() {
+	m.actionMenu = false
+	m.actions = nil
+	m.updateKeybindings()
+}
+
+// ActionsOpen returns whether the action menu is open.
+func (m Model) ActionsOpen() bool {
+	return m.actionMenu
+}
+
+// chooseAction closes the action menu and returns a command that sends an
+// ActionMsg for the given action on the selected item.
+func (m *Model) chooseAction(action Action) tea.Cmd {
+	m.CloseActions()
+	item, index := m.SelectedItem(), m.GlobalIndex()
+	if item == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return ActionMsg{Action: action, Index: index, Item: item}
+	}
+}
+
+// matchAction returns the action of the selected item whose key matches the
+// given key press, if any.
+func (m Model) matchAction(msg tea.KeyPressMsg) (Action, bool) {
+	for _, action := range m.ItemActions(m.SelectedItem()) {
+		if key.Matches(msg, action.Key) {
+			return action, true
+		}
+	}
+	return Action{}, false
+}
+
+// handleActionMenu handles messages while the action menu is open.
+func (m *Model) handleActionMenu(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.CloseActions):
+			m.CloseActions()
+		case key.Matches(msg, m.KeyMap.CursorUp):
+			m.actionCursor = max(0, m.actionCursor-1)
+		case key.Matches(msg, m.KeyMap.CursorDown):
+			m.actionCursor = min(len(m.actions)-1, m.actionCursor+1)
+		case key.Matches(msg, m.KeyMap.ChooseAction):
+			return m.chooseAction(m.actions[m.actionCursor])
+		default:
+			for _, action := range m.actions {
+				if key.Matches(msg, action.Key) {
+					return m.chooseAction(action)
+				}
+			}
+		}
+
+	case tea.MouseClickMsg:
+		if !m.MouseEnabled || msg.Button != tea.MouseLeft {
+			return nil
+		}
+		x, y := m.actionMenuPosition()
+		style := m.Styles.ActionMenu
+		row := msg.Y - y - style.GetMarginTop() - style.GetBorderTopSize() - style.GetPaddingTop()
+		inside := msg.X >= x && msg.X < x+lipgloss.Width(m.actionMenuView())
+		if !inside || row < 0 || row >= len(m.actions) {
+			m.CloseActions()
+			return nil
+		}
+		return m.chooseAction(m.actions[row])
+	}
+	return nil
+}
+
+// actionMenuView renders the action menu.
+func (m Model) actionMenuView() string {
+	width := 0
+	for _, action := range m.actions {
+		width = max(width, lipgloss.Width(m.actionEntry(action)))
+	}
+
+	lines := make([]string, len(m.actions))
+	for i, action := range m.actions {
+		style := m.Styles.ActionMenuItem
+		if i == m.actionCursor {
+			style = m.Styles.SelectedActionMenuItem
+		}
+		lines[i] = style.Width(width + style.GetHorizontalFrameSize()).Render(m.actionEntry(action))
+	}
+	return m.Styles.ActionMenu.Render(strings.Join(lines, "\n"))
+}
+
+// actionEntry renders the name and key of an action in the menu.
+func (m Model) actionEntry(action Action) string {
+	if k := action.Key.Help().Key; k != "" && action.Key.Enabled() {
+		return action.Name + " " + m.Styles.ActionMenuKey.Render(k)
+	}
+	return action.Name
+}
+
+// actionMenuPosition returns where the action menu is drawn: below the
+// selected item, or above it if there isn't enough room below.
+func (m Model) actionMenuPosition() (x, y int) {
+	itemsTop, paginationTop := m.layout()
+	itemX, itemY, itemHeight := m.itemOffset(m.Index())
+	x = itemX + m.Styles.ActionMenuOffset
+	y = itemsTop + itemY + itemHeight
+
+	menuHeight := lipgloss.Height(m.actionMenuView())
+	if y+menuHeight > max(paginationTop, m.height) && itemsTop+itemY-menuHeight >= 0 {
+		y = itemsTop + itemY - menuHeight
+	}
+	return x, y
+}
+
+// itemOffset returns where the visible item at the given index is rendered
+// relative to the top-left corner of the items, and its height.
+func (m Model) itemOffset(index int) (x, y, height int) {
+	items := m.VisibleItems()
+	if index < 0 || index >= len(items) {
+		return 0, 0, 0
+	}
+
+	start, end := m.pageBounds(len(items))
+	if columns := m.gridColumns(); columns > 0 {
+		d := m.delegate.(GridDelegate)
+		cell := index - start
+		return cell % columns * d.CellWidth(),
+			cell / columns * (m.delegate.Height() + m.delegate.Spacing()),
+			m.delegate.Height()
+	}
+	if m.scrolling() {
+		start, end = m.visibleRange(items)
+	}
+
+	for i := start; i < end && i <= index; i++ {
+		h := m.rowHeight(items, i)
+		if h == 0 {
+			continue
+		}
+		if y > 0 {
+			y += m.delegate.Spacing()
+		}
+		if i == index {
+			return 0, y, h
+		}
+		y += h
+	}
+	return 0, y, 0
+}
+
+// actionsHelp returns the keybindings of the selected item's actions.
+func (m Model) actionsHelp() []key.Binding {
+	var kb []key.Binding
+	for _, action := range m.ItemActions(m.SelectedItem()) {
+		if action.Key.Help().Key != "" {
+			kb = append(kb, action.Key)
+		}
+	}
+	return kb
+}
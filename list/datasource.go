@@ -0,0 +1,233 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// DefaultFetchSize is the default number of items loaded at once from a
+// DataSource.
+const DefaultFetchSize = 50
+
+// DataSource provides the items of a list on demand, such as from a paginated
+// API. See Model.SetDataSource.
+type DataSource interface {
+	// Len returns the total number of items, or -1 if it isn't known. When
+	// it isn't known, items are loaded until Fetch returns fewer items than
+	// requested.
+	Len() int
+
+	// Fetch returns the items from start up to, but not including, end. It
+	// runs in a tea.Cmd, so it may block. It may return fewer items than
+	// requested when the end of the data is reached.
+	Fetch(start, end int) ([]Item, error)
+}
+
+// fetchedMsg carries items loaded from a DataSource.
+type fetchedMsg struct {
+	id    int
+	gen   int
+	start int
+	end   int
+	items []Item
+	err   error
+}
+
+// SetDataSource sets a source to load the list's items from on demand,
+// replacing the current items. Items are loaded a chunk of FetchSize at a time
+// as the cursor nears them, and show as placeholders until they're loaded.
+// Until then, VisibleItems and Items hold nil for them. Filtering only
+// considers the items loaded so far, and sorting isn't supported. Errors
+// show as status messages, and the items that failed to load are only loaded
+// again with RetryFetch.
+//
+// Setting the items with SetItems removes the data source. This returns a
+// command.
+func (m *Model) SetDataSource(source DataSource) tea.Cmd {
+	m.resetFiltering()
+	m.dataSource = source
+	m.dataGen++
+	m.fetching = nil
+	m.fetchFailed = nil
+	m.dataComplete = false
+
+	n := source.Len()
+	if n >= 0 {
+		m.dataComplete = true
+	} else {
+		n = m.fetchSize()
+	}
+	m.items = make([]Item, n)
+	m.grouped = false
+	m.actionable = false
+	m.unsortedRank = nil
+	m.dropPositionalSelection()
+	m.invalidateFilter()
+
+	m.ResetSelected()
+	m.updatePagination()
+	m.updateKeybindings()
+	return m.fetchVisible()
+}
+
+// DataSource returns the list's data source, if any.
+func (m Model) DataSource() DataSource {
+	return m.dataSource
+}
+
+// Loading returns whether items are being loaded from the data source.
+func (m Model) Loading() bool {
+	return len(m.fetching) > 0
+}
+
+func (m Model) fetchSize() int {
+	if m.FetchSize > 0 {
+		return m.FetchSize
+	}
+	return DefaultFetchSize
+}
+
+// RetryFetch returns a command that loads the items near the cursor again
+// after loading them from the data source failed. Chunks of items that failed
+// to load aren't loaded again until then.
+func (m *Model) RetryFetch() tea.Cmd {
+	m.fetchFailed = nil
+	return m.fetchVisible()
+}
+
+// fetchVisible returns a command that loads the unloaded items on, and
+// around, the current page.
+func (m *Model) fetchVisible() tea.Cmd {
+	if m.dataSource == nil || len(m.items) == 0 {
+		return nil
+	}
+
+	size := m.fetchSize()
+	start, end := m.pageBounds(len(m.items))
+	if m.scrolling() {
+		start, end = m.visibleRange(m.items)
+	}
+	if m.filterState != Unfiltered {
+		// Filtered items only include loaded items.
+		start, end = m.Index(), m.Index()+1
+	}
+	start = max(0, start-size/2)        //nolint:mnd
+	end = min(len(m.items), end+size/2) //nolint:mnd
+
+	var cmds []tea.Cmd
+	for chunk := start / size * size; chunk < end; chunk += size {
+		if _, ok := m.fetching[chunk]; ok {
+			continue
+		}
+		if _, ok := m.fetchFailed[chunk]; ok {
+			continue
+		}
+		chunkEnd := min(chunk+size, len(m.items))
+		if !m.hasUnloaded(chunk, chunkEnd) {
+			continue
+		}
+		cmds = append(cmds, m.fetch(chunk, chunkEnd))
+	}
+	if len(cmds) > 0 && !m.showSpinner {
+		m.loadingSpinner = true
+		cmds = append(cmds, m.StartSpinner())
+	}
+	return tea.Batch(cmds...)
+}
+
+// hasUnloaded returns whether any items between start and end haven't been
+// loaded.
+func (m Model) hasUnloaded(start, end int) bool {
+	for _, item := range m.items[start:end] {
+		if item == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch returns a command that loads the items from start to end.
+func (m *Model) fetch(start, end int) tea.Cmd {
+	if m.fetching == nil {
+		m.fetching = make(map[int]struct{})
+	}
+	m.fetching[start] = struct{}{}
+
+	source, id, gen := m.dataSource, m.id, m.dataGen
+	return func() tea.Msg {
+		items, err := source.Fetch(start, end)
+		return fetchedMsg{id: id, gen: gen, start: start, end: end, items: items, err: err}
+	}
+}
+
+// handleFetched stores the items loaded from the data source.
+func (m *Model) handleFetched(msg fetchedMsg) tea.Cmd {
+	if msg.id != m.id || msg.gen != m.dataGen {
+		return nil
+	}
+	delete(m.fetching, msg.start)
+
+	var cmds []tea.Cmd
+	if msg.err != nil {
+		// Don't load the chunk again until asked to, see RetryFetch.
+		if m.fetchFailed == nil {
+			m.fetchFailed = make(map[int]struct{})
+		}
+		m.fetchFailed[msg.start] = struct{}{}
+		cmds = append(cmds, m.NewStatusMessage(fmt.Sprintf("Error: %v", msg.err)))
+	} else {
+		n := min(len(msg.items), len(m.items)-msg.start)
+		copy(m.items[msg.start:], msg.items[:n])
+		m.grouped = m.grouped || anyGrouped(msg.items[:n])
+		m.actionable = m.actionable || anyActionItem(msg.items[:n])
+
+		switch {
+		case len(msg.items) < msg.end-msg.start:
+			// We've reached the end of the data.
+			m.items = m.items[:msg.start+len(msg.items)]
+			m.dataComplete = true
+		case !m.dataComplete && msg.end == len(m.items):
+			// There may be more, so add placeholders for the next chunk.
+			m.items = append(m.items, make([]Item, m.fetchSize())...)
+		}
+
+		if m.filterState != Unfiltered {
+			m.invalidateFilter()
+			cmds = append(cmds, m.filterItems())
+		}
+		m.updatePagination()
+		m.updateKeybindings()
+	}
+
+	if !m.Loading() && m.loadingSpinner {
+		m.loadingSpinner = false
+		m.StopSpinner()
+	}
+	if msg.err == nil {
+		cmds = append(cmds, m.fetchVisible())
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadedItems returns the number of items that have been loaded.
+func (m Model) loadedItems() int {
+	if m.dataSource == nil {
+		return len(m.items)
+	}
+	n := 0
+	for _, item := range m.items {
+		if item != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// placeholderView renders an item that hasn't been loaded yet.
+func (m Model) placeholderView(index int) string {
+	lines := make([]string, m.itemHeight(index, nil))
+	lines[0] = m.Styles.Placeholder.Render(m.spinnerView() + " Loading" + ellipsis)
+	return strings.Join(lines, "\n")
+}
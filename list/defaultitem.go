@@ -28,6 +28,10 @@ type DefaultItemStyles struct {
 
 	// Characters matching the current filter, if any.
 	FilterMatch lipgloss.Style
+
+	// The checkmark column shown in multi-select mode.
+	CheckedMark   lipgloss.Style
+	UncheckedMark lipgloss.Style
 }
 
 // NewDefaultItemStyles returns style definitions for a default item. See
@@ -60,6 +64,14 @@ func NewDefaultItemStyles(isDark bool) (s DefaultItemStyles) {
 
 	s.FilterMatch = lipgloss.NewStyle().Underline(true)
 
+	s.CheckedMark = lipgloss.NewStyle().
+		Foreground(lightDark(lipgloss.Color("#04B575"), lipgloss.Color("#04B575"))).
+		SetString("✓ ")
+
+	s.UncheckedMark = lipgloss.NewStyle().
+		Foreground(lightDark(lipgloss.Color("#DDDADA"), lipgloss.Color("#3C3C3C"))).
+		SetString("· ")
+
 	return s
 }
 
@@ -91,6 +103,8 @@ type DefaultDelegate struct {
 	FullHelpFunc    func() [][]key.Binding
 	height          int
 	spacing         int
+	isDark          bool
+	customStyles    bool
 }
 
 // NewDefaultDelegate creates a new delegate with default styles.
@@ -99,11 +113,12 @@ func NewDefaultDelegate() DefaultDelegate {
 	const defaultSpacing = 1
 	return DefaultDelegate{
 		ShowDescription: true,
-		// XXX: Let the user choose between light and dark colors. We've
-		// temporarily hardcoded the dark colors here.
+		// Dark styles are used until the list switches them with
+		// SetDarkMode.
 		Styles:  NewDefaultItemStyles(true),
 		height:  defaultHeight,
 		spacing: defaultSpacing,
+		isDark:  true,
 	}
 }
 
@@ -160,8 +175,25 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 		return
 	}
 
+	// In multi-select mode, reserve a column for the checkmark.
+	var mark, markGap string
+	if m.MultiSelect() {
+		mark = s.UncheckedMark.String()
+		if m.IsSelected(index) {
+			mark = s.CheckedMark.String()
+		}
+		markGap = strings.Repeat(" ", lipgloss.Width(mark))
+	}
+	renderTitle := func(style lipgloss.Style, title string) string {
+		if mark == "" {
+			return style.Render(title)
+		}
+		// Style the title separately so the mark's styling doesn't reset it.
+		return style.Render(mark + style.Inline(true).Render(title))
+	}
+
 	// Prevent text from exceeding list width
-	textwidth := m.width - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+	textwidth := m.width - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight() - lipgloss.Width(mark)
 	title = ansi.Truncate(title, textwidth, ellipsis)
 	if d.ShowDescription {
 		var lines []string
@@ -187,8 +219,8 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 	}
 
 	if emptyFilter {
-		title = s.DimmedTitle.Render(title)
-		desc = s.DimmedDesc.Render(desc)
+		title = renderTitle(s.DimmedTitle, title)
+		desc = s.DimmedDesc.Render(indentLines(desc, markGap))
 	} else if isSelected && m.FilterState() != Filtering {
 		if isFiltered {
 			// Highlight matches
@@ -196,8 +228,8 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 			matched := unmatched.Inherit(s.FilterMatch)
 			title = lipgloss.StyleRunes(title, matchedRunes, matched, unmatched)
 		}
-		title = s.SelectedTitle.Render(title)
-		desc = s.SelectedDesc.Render(desc)
+		title = renderTitle(s.SelectedTitle, title)
+		desc = s.SelectedDesc.Render(indentLines(desc, markGap))
 	} else {
 		if isFiltered {
 			// Highlight matches
@@ -205,8 +237,8 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 			matched := unmatched.Inherit(s.FilterMatch)
 			title = lipgloss.StyleRunes(title, matchedRunes, matched, unmatched)
 		}
-		title = s.NormalTitle.Render(title)
-		desc = s.NormalDesc.Render(desc)
+		title = renderTitle(s.NormalTitle, title)
+		desc = s.NormalDesc.Render(indentLines(desc, markGap))
 	}
 
 	if d.ShowDescription {
@@ -231,3 +263,11 @@ func (d DefaultDelegate) FullHelp() [][]key.Binding {
 	}
 	return nil
 }
+
+// indentLines prefixes every line of s with indent.
+func indentLines(s, indent string) string {
+	if indent == "" {
+		return s
+	}
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
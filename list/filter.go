@@ -0,0 +1,225 @@
+package list
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Internal ID management. Used to route filter results to the list that
+// requested them.
+var lastID int64
+
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// filterChunkSize is the number of items each worker filters at once when
+// filtering in parallel. Cancellation is checked between chunks.
+const filterChunkSize = 10_000
+
+// ItemFilterFunc is like FilterFunc, but receives the items themselves rather
+// than their filter values, so it can filter on more than Item.FilterValue.
+// See QueryFilter for an example.
+type ItemFilterFunc func(string, []Item) []Rank
+
+// FilterMatchesMsg contains data about items matched during filtering. The
+// message should be routed to Update for processing.
+//
+// Deprecated: the list no longer produces FilterMatchesMsg. Filter results
+// are routed internally so that results for superseded queries and other
+// lists can be discarded. Use FilterState and VisibleItems to notice when
+// filtering has finished.
+type FilterMatchesMsg []filteredItem
+
+// filterMatchesMsg carries the items matched by the filter the list
+// requested. Results for a query that has since been superseded are
+// discarded.
+type filterMatchesMsg struct {
+	id      int
+	tag     int
+	query   string
+	matches filteredItems
+}
+
+// filterDebounceMsg starts filtering once the filter input has been idle for
+// Model.FilterDebounce.
+type filterDebounceMsg struct {
+	id  int
+	tag int
+}
+
+// requestFilter requests updated filtering for the current filter value,
+// waiting for FilterDebounce first if it's set. Any filtering still in
+// progress is cancelled.
+func (m *Model) requestFilter() tea.Cmd {
+	if m.FilterDebounce <= 0 {
+		return m.filterItems()
+	}
+	m.cancelFilter()
+	m.filterTag++
+	id, tag := m.id, m.filterTag
+	return tea.Tick(m.FilterDebounce, func(time.Time) tea.Msg {
+		return filterDebounceMsg{id: id, tag: tag}
+	})
+}
+
+// cancelFilter cancels filtering in progress, if any.
+func (m *Model) cancelFilter() {
+	if m.filterCancel != nil {
+		m.filterCancel()
+		m.filterCancel = nil
+	}
+}
+
+// filterItems returns a command that filters the items against the current
+// filter value. Starting a new filter cancels the previous one, and only the
+// results of the latest filter are applied.
+func (m *Model) filterItems() tea.Cmd {
+	m.cancelFilter()
+	m.filterTag++
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.filterCancel = cancel
+
+	query := m.FilterInput.Value()
+	id, tag := m.id, m.filterTag
+
+	if query == "" || m.filterState == Unfiltered {
+		all := m.itemsAsFilterItems()
+		return func() tea.Msg {
+			return filterMatchesMsg{id: id, tag: tag, matches: all} // return nothing
+		}
+	}
+
+	// When the query extends the query the current matches were produced
+	// with, only those matches need to be searched again.
+	items, indexes := m.items, []int(nil)
+	if m.IncrementalFiltering && m.refinable && m.filterQuery != "" &&
+		strings.HasPrefix(query, m.filterQuery) &&
+		(m.ItemFilter == nil || refinableQuery(m.filterQuery, query)) {
+		indexes = make([]int, len(m.filteredItems))
+		for i, fi := range m.filteredItems {
+			indexes[i] = fi.index
+		}
+		// Restore the original item order so ties rank as they would when
+		// filtering all items.
+		slices.Sort(indexes)
+	} else if m.dataSource != nil {
+		// Only filter items loaded from the data source.
+		for i, item := range items {
+			if item != nil {
+				indexes = append(indexes, i)
+			}
+		}
+	}
+
+	filter, itemFilter, workers := m.Filter, m.ItemFilter, m.FilterWorkers
+
+	return func() tea.Msg {
+		defer cancel()
+
+		candidates := items
+		if indexes != nil {
+			candidates = make([]Item, len(indexes))
+			for i, index := range indexes {
+				candidates[i] = items[index]
+			}
+		}
+
+		var filterRange func(start, end int) []Rank
+		if itemFilter != nil {
+			filterRange = func(start, end int) []Rank {
+				return itemFilter(query, candidates[start:end])
+			}
+		} else {
+			targets := make([]string, len(candidates))
+			for i, t := range candidates {
+				targets[i] = t.FilterValue()
+			}
+			filterRange = func(start, end int) []Rank {
+				return filter(query, targets[start:end])
+			}
+		}
+
+		ranks := runFilter(ctx, filterRange, len(candidates), workers)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		filterMatches := make(filteredItems, 0, len(ranks))
+		for _, r := range ranks {
+			index := r.Index
+			if indexes != nil {
+				index = indexes[index]
+			}
+			filterMatches = append(filterMatches, filteredItem{
+				index:   index,
+				item:    items[index],
+				matches: r.MatchedIndexes,
+			})
+		}
+
+		return filterMatchesMsg{id: id, tag: tag, query: query, matches: filterMatches}
+	}
+}
+
+// runFilter filters n candidates, calling filter with ranges of candidates.
+// With more than one worker, the candidates are split into chunks which are
+// filtered concurrently, and the results are merged by score.
+func runFilter(ctx context.Context, filter func(start, end int) []Rank, n, workers int) []Rank {
+	if workers <= 1 || n <= filterChunkSize {
+		return filter(0, n)
+	}
+
+	chunks := (n + filterChunkSize - 1) / filterChunkSize
+	results := make([][]Rank, chunks)
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, chunks) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range next {
+				start := c * filterChunkSize
+				end := min(start+filterChunkSize, n)
+				ranks := filter(start, end)
+				for i := range ranks {
+					ranks[i].Index += start
+				}
+				results[c] = ranks
+			}
+		}()
+	}
+
+	for c := range chunks {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- c
+	}
+	close(next)
+	wg.Wait()
+
+	var ranks []Rank
+	for _, r := range results {
+		ranks = append(ranks, r...)
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		return ranks[i].Score > ranks[j].Score
+	})
+	return ranks
+}
+
+// invalidateFilter marks the current matches as unsuitable for incremental
+// filtering, such as after the items have changed.
+func (m *Model) invalidateFilter() {
+	m.filterQuery, m.refinable = "", false
+}
@@ -0,0 +1,152 @@
+package list
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// GridDelegate is an ItemDelegate that lays items out in a grid, such as for
+// pickers of emoji, colors or short tags. When the delegate implements this
+// interface, items flow into as many columns as fit in the list's width, the
+// cursor moves in two dimensions, and each page holds as many rows as fit in
+// the available height. Cells are Height lines tall and rows are separated by
+// Spacing lines, as in a regular list. Items rendered wider or taller than a
+// cell are truncated.
+//
+// Groups and continuous scrolling aren't supported in a grid.
+type GridDelegate interface {
+	ItemDelegate
+
+	// CellWidth returns the width of each cell of the grid, including any
+	// gap between cells.
+	CellWidth() int
+}
+
+// gridColumns returns the number of columns of the grid, or 0 if the items
+// aren't laid out in a grid.
+func (m Model) gridColumns() int {
+	d, ok := m.delegate.(GridDelegate)
+	if !ok {
+		return 0
+	}
+	return max(1, m.width/max(1, d.CellWidth()))
+}
+
+// isGrid returns whether the items are laid out in a grid.
+func (m Model) isGrid() bool {
+	return m.gridColumns() > 0
+}
+
+// CursorLeft moves the cursor to the previous item in a grid. This can also
+// move to the previous page. Outside of a grid, it does nothing.
+func (m *Model) CursorLeft() {
+	if m.isGrid() {
+		m.cursorUp()
+	}
+}
+
+// CursorRight moves the cursor to the next item in a grid. This can also move
+// to the next page. Outside of a grid, it does nothing.
+func (m *Model) CursorRight() {
+	if m.isGrid() {
+		m.cursorDown()
+	}
+}
+
+// gridCursorUp moves the cursor up a row in a grid, going to the same column
+// on the previous page from the first row.
+func (m *Model) gridCursorUp() {
+	columns := m.gridColumns()
+	if m.cursor >= columns {
+		m.cursor -= columns
+		return
+	}
+	if m.Paginator.OnFirstPage() {
+		if m.InfiniteScrolling {
+			m.GoToEnd()
+		}
+		return
+	}
+
+	column := m.cursor
+	m.Paginator.PrevPage()
+	maxCursorIndex := m.maxCursorIndex()
+	m.cursor = min(maxCursorIndex/columns*columns+column, maxCursorIndex)
+}
+
+// gridCursorDown moves the cursor down a row in a grid, going to the same
+// column on the next page from the last row.
+func (m *Model) gridCursorDown() {
+	columns := m.gridColumns()
+	maxCursorIndex := m.maxCursorIndex()
+	switch {
+	case m.cursor+columns <= maxCursorIndex:
+		m.cursor += columns
+	case m.cursor/columns < maxCursorIndex/columns:
+		// The last row is shorter than this one.
+		m.cursor = maxCursorIndex
+	case !m.Paginator.OnLastPage():
+		column := m.cursor % columns
+		m.Paginator.NextPage()
+		m.cursor = min(column, m.maxCursorIndex())
+	case m.InfiniteScrolling:
+		m.GoToStart()
+	}
+}
+
+// gridCellAt returns the index of the item in the grid cell at the given
+// coordinates, relative to the top-left corner of the items.
+func (m Model) gridCellAt(x, y int) (int, bool) {
+	items := m.VisibleItems()
+	d, ok := m.delegate.(GridDelegate)
+	if !ok || len(items) == 0 {
+		return 0, false
+	}
+
+	rowHeight := m.delegate.Height() + m.delegate.Spacing()
+	column := x / max(1, d.CellWidth())
+	if rowHeight <= 0 || y%rowHeight >= m.delegate.Height() || column >= m.gridColumns() {
+		return 0, false
+	}
+
+	start, end := m.pageBounds(len(items))
+	index := start + y/rowHeight*m.gridColumns() + column
+	if index >= end {
+		return 0, false
+	}
+	return index, true
+}
+
+// gridView renders the items on the current page in a grid.
+func (m Model) gridView() string {
+	items := m.VisibleItems()
+	d := m.delegate.(GridDelegate)
+	columns := m.gridColumns()
+	cellStyle := lipgloss.NewStyle().
+		Width(d.CellWidth()).MaxWidth(d.CellWidth()).
+		Height(m.delegate.Height()).MaxHeight(m.delegate.Height())
+
+	start, end := m.pageBounds(len(items))
+	var rows []string
+	for rowStart := start; rowStart < end; rowStart += columns {
+		var cells []string
+		for index := rowStart; index < min(rowStart+columns, end); index++ {
+			var b strings.Builder
+			if items[index] == nil {
+				b.WriteString(m.placeholderView(index))
+			} else {
+				m.delegate.Render(&b, m, index, items[index])
+			}
+			cells = append(cells, cellStyle.Render(b.String()))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+
+	// Fill up the rest of the page, as with a regular list.
+	rowsPerPage := m.Paginator.PerPage / columns
+	for len(rows) < rowsPerPage {
+		rows = append(rows, strings.Repeat("\n", m.delegate.Height()-1))
+	}
+	return strings.Join(rows, strings.Repeat("\n", m.delegate.Spacing()+1))
+}
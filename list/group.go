@@ -0,0 +1,189 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupedItem is an item that belongs to a group. When items are grouped,
+// the list renders a section header above each group, and the user can
+// collapse groups with KeyMap.ToggleGroup. Items of the same group are
+// expected to be next to each other, which SortOrder can help with.
+type GroupedItem interface {
+	Item
+
+	// Group returns the name of the item's group. Items with an empty group
+	// name aren't grouped.
+	Group() string
+}
+
+// groupOf returns the name of the group of the given item.
+func groupOf(item Item) string {
+	if g, ok := item.(GroupedItem); ok {
+		return g.Group()
+	}
+	return ""
+}
+
+// hasGroups returns whether the items are grouped.
+func (m Model) hasGroups() bool {
+	return m.grouped && !m.isGrid()
+}
+
+// anyGrouped returns whether any of the given items are grouped.
+func anyGrouped(items []Item) bool {
+	for _, item := range items {
+		if groupOf(item) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// SetGroupCollapsed collapses or expands the given group. Collapsed groups
+// only show their header. Groups are always expanded while filtering.
+func (m *Model) SetGroupCollapsed(group string, collapsed bool) {
+	if collapsed {
+		if m.collapsed == nil {
+			m.collapsed = make(map[string]struct{})
+		}
+		m.collapsed[group] = struct{}{}
+	} else {
+		delete(m.collapsed, group)
+	}
+	m.updateGroups()
+}
+
+// GroupCollapsed returns whether the given group is collapsed.
+func (m Model) GroupCollapsed(group string) bool {
+	_, ok := m.collapsed[group]
+	return ok
+}
+
+// ToggleGroup collapses the group of the selected item, or expands it if it's
+// collapsed.
+func (m *Model) ToggleGroup() {
+	if item := m.cursorItem(); item != nil {
+		if group := groupOf(item); group != "" {
+			m.SetGroupCollapsed(group, !m.GroupCollapsed(group))
+		}
+	}
+}
+
+// CollapseAllGroups collapses every group.
+func (m *Model) CollapseAllGroups() {
+	for _, item := range m.items {
+		if group := groupOf(item); group != "" {
+			if m.collapsed == nil {
+				m.collapsed = make(map[string]struct{})
+			}
+			m.collapsed[group] = struct{}{}
+		}
+	}
+	m.updateGroups()
+}
+
+// ExpandAllGroups expands every group.
+func (m *Model) ExpandAllGroups() {
+	m.collapsed = nil
+	m.updateGroups()
+}
+
+// updateGroups updates the pagination after groups have been collapsed or
+// expanded.
+func (m *Model) updateGroups() {
+	m.updatePagination()
+	m.skipCollapsed()
+	m.updateKeybindings()
+}
+
+// isGroupHeader returns whether a group header is rendered above the visible
+// item at the given index.
+func (m Model) isGroupHeader(items []Item, index int) bool {
+	if m.isGrid() {
+		return false
+	}
+	group := groupOf(items[index])
+	return group != "" && (index == 0 || groupOf(items[index-1]) != group)
+}
+
+// isCollapsed returns whether the visible item at the given index belongs to
+// a collapsed group.
+func (m Model) isCollapsed(items []Item, index int) bool {
+	if len(m.collapsed) == 0 || m.filterState != Unfiltered || m.isGrid() {
+		return false
+	}
+	_, ok := m.collapsed[groupOf(items[index])]
+	return ok
+}
+
+// isHidden returns whether the visible item at the given index is hidden in
+// a collapsed group. The first item of a collapsed group isn't hidden, but
+// stands in for the group and is rendered as its header.
+func (m Model) isHidden(items []Item, index int) bool {
+	return m.isCollapsed(items, index) && !m.isGroupHeader(items, index)
+}
+
+// onCollapsedHeader returns whether the cursor is on the header of a collapsed
+// group. Headers aren't items, so there's no selected item.
+func (m Model) onCollapsedHeader() bool {
+	items := m.VisibleItems()
+	index := m.Index()
+	return index >= 0 && index < len(items) &&
+		m.isCollapsed(items, index) && m.isGroupHeader(items, index)
+}
+
+// skipCollapsed moves the cursor off an item hidden in a collapsed group and
+// onto the group's header.
+func (m *Model) skipCollapsed() {
+	items := m.VisibleItems()
+	index := m.Index()
+	if index < 0 || index >= len(items) || !m.isHidden(items, index) {
+		return
+	}
+	for index > 0 && m.isHidden(items, index) {
+		index--
+	}
+	m.Select(index)
+}
+
+// rowHeight returns the number of lines taken up by the visible item at the
+// given index, including its group header.
+func (m Model) rowHeight(items []Item, index int) int {
+	switch {
+	case m.isHidden(items, index):
+		return 0
+	case !m.isGroupHeader(items, index):
+		return m.itemHeight(index, items[index])
+	case m.isCollapsed(items, index):
+		return m.groupHeaderHeight(items, index)
+	default:
+		return m.groupHeaderHeight(items, index) + m.itemHeight(index, items[index])
+	}
+}
+
+// groupHeaderHeight returns the height of the group header above the visible
+// item at the given index.
+func (m Model) groupHeaderHeight(items []Item, index int) int {
+	return strings.Count(m.groupHeaderView(items, index), "\n") + 1
+}
+
+// groupHeaderView renders the group header above the visible item at the
+// given index.
+func (m Model) groupHeaderView(items []Item, index int) string {
+	group := groupOf(items[index])
+
+	if !m.isCollapsed(items, index) {
+		return m.Styles.GroupHeader.Render(expandedMark + " " + group)
+	}
+
+	count := 1
+	for i := index + 1; i < len(items) && m.isHidden(items, i); i++ {
+		count++
+	}
+	header := fmt.Sprintf("%s %s (%d)", collapsedMark, group, count)
+	if index == m.Index() {
+		return m.Styles.SelectedGroupHeader.Render(header)
+	}
+	return m.Styles.GroupHeader.Render(header)
+}
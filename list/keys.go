@@ -8,6 +8,8 @@ type KeyMap struct {
 	// Keybindings used when browsing the list.
 	CursorUp    key.Binding
 	CursorDown  key.Binding
+	CursorLeft  key.Binding
+	CursorRight key.Binding
 	NextPage    key.Binding
 	PrevPage    key.Binding
 	GoToStart   key.Binding
@@ -15,6 +17,30 @@ type KeyMap struct {
 	Filter      key.Binding
 	ClearFilter key.Binding
 
+	// Keybindings used in multi-select mode.
+	ToggleSelect    key.Binding
+	SelectAll       key.Binding
+	SelectNone      key.Binding
+	InvertSelection key.Binding
+
+	// Keybindings used to sort the list.
+	CycleSortOrder      key.Binding
+	ToggleSortDirection key.Binding
+
+	// Keybinding used to collapse and expand groups of items.
+	ToggleGroup key.Binding
+
+	// Keybindings used to reorder the list.
+	MoveItemUp       key.Binding
+	MoveItemDown     key.Binding
+	MoveItemToTop    key.Binding
+	MoveItemToBottom key.Binding
+
+	// Keybindings used to open the action menu, and within it.
+	ShowActions  key.Binding
+	ChooseAction key.Binding
+	CloseActions key.Binding
+
 	// Keybindings used when setting a filter.
 	CancelWhileFiltering key.Binding
 	AcceptWhileFiltering key.Binding
@@ -42,6 +68,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		CursorLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "left"),
+		),
+		CursorRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "right"),
+		),
 		PrevPage: key.NewBinding(
 			key.WithKeys("left", "h", "pgup", "b", "u"),
 			key.WithHelp("←/h/pgup", "prev page"),
@@ -67,6 +101,72 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("esc", "clear filter"),
 		),
 
+		// Multi-select.
+		ToggleSelect: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("space", "toggle"),
+		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "select all"),
+		),
+		SelectNone: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "select none"),
+		),
+		InvertSelection: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "invert selection"),
+		),
+
+		// Sorting.
+		CycleSortOrder: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+		ToggleSortDirection: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse sort"),
+		),
+
+		// Groups.
+		ToggleGroup: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "toggle group"),
+		),
+
+		// Reordering.
+		MoveItemUp: key.NewBinding(
+			key.WithKeys("alt+up", "K"),
+			key.WithHelp("K/alt+↑", "move up"),
+		),
+		MoveItemDown: key.NewBinding(
+			key.WithKeys("alt+down", "J"),
+			key.WithHelp("J/alt+↓", "move down"),
+		),
+		MoveItemToTop: key.NewBinding(
+			key.WithKeys("alt+home"),
+			key.WithHelp("alt+home", "move to top"),
+		),
+		MoveItemToBottom: key.NewBinding(
+			key.WithKeys("alt+end"),
+			key.WithHelp("alt+end", "move to bottom"),
+		),
+
+		// Actions.
+		ShowActions: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "actions"),
+		),
+		ChooseAction: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "choose"),
+		),
+		CloseActions: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+
 		// Filtering.
 		CancelWhileFiltering: key.NewBinding(
 			key.WithKeys("esc"),
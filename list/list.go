@@ -5,6 +5,7 @@ package list
 
 import (
 	"cmp"
+	"context"
 	"fmt"
 	"io"
 	"sort"
@@ -60,6 +61,19 @@ type ItemDelegate interface {
 	Update(msg tea.Msg, m *Model) tea.Cmd
 }
 
+// VariableHeightDelegate is an ItemDelegate whose items don't all have the
+// same height, such as a list mixing single and multi-line entries. When the
+// delegate implements this interface, pages are filled with as many items as
+// fit in the available height rather than a fixed number of items per page,
+// and Height is no longer used.
+type VariableHeightDelegate interface {
+	ItemDelegate
+
+	// ItemHeight returns the height of the given item in lines. The index is
+	// the index of the item in the filtered list of items.
+	ItemHeight(index int, item Item) int
+}
+
 type filteredItem struct {
 	index   int   // index in the unfiltered list
 	item    Item  // item matched
@@ -76,10 +90,6 @@ func (f filteredItems) items() []Item {
 	return agg
 }
 
-// FilterMatchesMsg contains data about items matched during filtering. The
-// message should be routed to Update for processing.
-type FilterMatchesMsg []filteredItem
-
 // FilterFunc takes a term and a list of strings to search through
 // (defined by Item#FilterValue).
 // It should return a sorted list of ranks.
@@ -89,8 +99,13 @@ type FilterFunc func(string, []string) []Rank
 type Rank struct {
 	// The index of the item in the original input.
 	Index int
-	// Indices of the actual word that were matched against the filter term.
+	// Indices of the runes of the filter value that were matched against
+	// the filter term.
 	MatchedIndexes []int
+	// Score of the match. Higher scores rank first when the results of
+	// filtering in parallel are merged. Filters that don't sort their results
+	// should leave it at zero.
+	Score int
 }
 
 // DefaultFilter uses the sahilm/fuzzy to filter through the list.
@@ -102,7 +117,8 @@ func DefaultFilter(term string, targets []string) []Rank {
 	for i, r := range ranks {
 		result[i] = Rank{
 			Index:          r.Index,
-			MatchedIndexes: r.MatchedIndexes,
+			MatchedIndexes: runeIndexes(r.Str, r.MatchedIndexes),
+			Score:          r.Score,
 		}
 	}
 	return result
@@ -116,7 +132,7 @@ func UnsortedFilter(term string, targets []string) []Rank {
 	for i, r := range ranks {
 		result[i] = Rank{
 			Index:          r.Index,
-			MatchedIndexes: r.MatchedIndexes,
+			MatchedIndexes: runeIndexes(r.Str, r.MatchedIndexes),
 		}
 	}
 	return result
@@ -145,12 +161,21 @@ func (f FilterState) String() string {
 
 // Model contains the state of this component.
 type Model struct {
-	showTitle        bool
-	showFilter       bool
-	showStatusBar    bool
-	showPagination   bool
-	showHelp         bool
-	filteringEnabled bool
+	showTitle         bool
+	showFilter        bool
+	showStatusBar     bool
+	showPagination    bool
+	showHelp          bool
+	filteringEnabled  bool
+	multiSelect       bool
+	reorderingEnabled bool
+
+	// Continuous scrolling state. scrollOffset is the index of the first
+	// visible item and itemsHeight the height available to items.
+	continuousScrolling bool
+	scrollOff           int
+	scrollOffset        int
+	itemsHeight         int
 
 	itemNameSingular string
 	itemNamePlural   string
@@ -165,6 +190,50 @@ type Model struct {
 	// Filter is used to filter the list.
 	Filter FilterFunc
 
+	// ItemFilter, if set, is used to filter the list instead of Filter. Set
+	// it to QueryFilter to filter with structured queries.
+	ItemFilter ItemFilterFunc
+
+	// SortFilterResults orders filter results by the current sort order
+	// rather than by how well they match the filter.
+	SortFilterResults bool
+
+	// MouseEnabled enables selecting items and changing pages with the mouse,
+	// and scrolling with the mouse wheel. Mouse events must also be enabled
+	// in the program. Mouse coordinates are expected to be relative to the
+	// list, see ItemAt.
+	MouseEnabled bool
+
+	// MouseWheelDelta is the number of items to move the cursor by per
+	// mouse wheel event. By default this is 1.
+	MouseWheelDelta int
+
+	// FetchSize is the number of items to load at once from a DataSource. By
+	// default this is DefaultFetchSize.
+	FetchSize int
+
+	// DoubleClickInterval is the longest time between two clicks on an item
+	// for them to count as a double-click, which sends an ItemChosenMsg. By
+	// default this is 500 milliseconds.
+	DoubleClickInterval time.Duration
+
+	// FilterDebounce is how long to wait after the filter input last changed
+	// before filtering. By default filtering starts immediately.
+	FilterDebounce time.Duration
+
+	// FilterWorkers is the number of goroutines to filter large lists with.
+	// The items are split into chunks that are filtered concurrently and
+	// merged by Rank.Score. By default, all items are filtered at once.
+	FilterWorkers int
+
+	// IncrementalFiltering refines the current matches, rather than filtering
+	// all items again, when the filter is extended by typing more characters.
+	// Only enable it if extending a query never matches additional items, as
+	// is the case with DefaultFilter. With an ItemFilter, queries with the
+	// negated, anchored or regular expression terms of QueryFilter are always
+	// filtered from scratch.
+	IncrementalFiltering bool
+
 	disableQuitKeybindings bool
 
 	// Additional key mappings for the short and full help views. This allows
@@ -200,13 +269,82 @@ type Model struct {
 	// this field should be considered ephemeral.
 	filteredItems filteredItems
 
+	// Filtering state. filterTag identifies the latest filter request,
+	// filterQuery is the query filteredItems were produced with, and
+	// refinable reports whether they can be refined by incremental filtering.
+	id           int
+	filterTag    int
+	filterCancel context.CancelFunc
+	filterQuery  string
+	refinable    bool
+
+	// Items selected in multi-select mode, keyed by selectionKey.
+	selected map[any]struct{}
+
+	// Names of collapsed groups.
+	collapsed map[string]struct{}
+
+	// Whether any item is grouped. It's recorded as items are added, rather
+	// than checked on every update, so it may stay set after the grouped
+	// items are removed.
+	grouped bool
+
+	// Items are loaded on demand from dataSource, if set. dataGen identifies
+	// the current data source, fetching and fetchFailed hold the start of
+	// chunks being loaded and chunks that failed to load, and dataComplete
+	// reports whether the number of items is known.
+	dataSource     DataSource
+	dataGen        int
+	fetching       map[int]struct{}
+	fetchFailed    map[int]struct{}
+	dataComplete   bool
+	loadingSpinner bool
+
+	// The last click, used to detect double-clicks.
+	lastClick      time.Time
+	lastClickIndex int
+
+	// Whether an item is being dragged with the mouse to reorder the list,
+	// and the index it was dragged from.
+	dragging bool
+	dragFrom int
+
+	// Sort orders the user can cycle through, and the index of the current
+	// one, or -1 if the items aren't sorted.
+	sortOrders     []SortOrder
+	sortIndex      int
+	sortDescending bool
+
+	// While the items are sorted, the position of each item in the order
+	// the items were given in, so that order can be restored.
+	unsortedRank []int
+
+	// The index of the first item on each page when the delegate is a
+	// VariableHeightDelegate, in which case the number of items per page
+	// varies. Nil otherwise.
+	pageStarts []int
+
 	delegate ItemDelegate
+
+	// Whether any item is an ActionItem, recorded as items are added like
+	// grouped.
+	actionable bool
+
+	// The action menu, the actions it offers, and the selected one.
+	actionMenu   bool
+	actions      []Action
+	actionCursor int
+
+	// Whether the default styles are the dark ones, and whether the
+	// application set its own styles with SetStyles. See SetDarkMode.
+	isDark       bool
+	customStyles bool
 }
 
 // New returns a new model with sensible defaults.
 func New(items []Item, delegate ItemDelegate, width, height int) Model {
-	// XXX: Let the user choose between light and dark colors. We've
-	// temporarily hardcoded the dark colors here.
+	// Dark styles are used until the terminal's background color is known.
+	// See SetDarkMode.
 	styles := DefaultStyles(true)
 
 	sp := spinner.New()
@@ -238,14 +376,22 @@ func New(items []Item, delegate ItemDelegate, width, height int) Model {
 		Title:                 "List",
 		FilterInput:           filterInput,
 		StatusMessageLifetime: time.Second,
-
-		width:     width,
-		height:    height,
-		delegate:  delegate,
-		items:     items,
-		Paginator: p,
-		spinner:   sp,
-		Help:      help.New(),
+		scrollOff:             2,
+		sortIndex:             -1,
+		MouseWheelDelta:       1,
+		DoubleClickInterval:   500 * time.Millisecond, //nolint:mnd
+
+		id:         nextID(),
+		isDark:     true,
+		width:      width,
+		height:     height,
+		delegate:   delegate,
+		items:      items,
+		grouped:    anyGrouped(items),
+		actionable: anyActionItem(items),
+		Paginator:  p,
+		spinner:    sp,
+		Help:       help.New(),
 	}
 
 	m.updatePagination()
@@ -280,10 +426,12 @@ func (m *Model) SetShowTitle(v bool) {
 func (m *Model) SetFilterText(filter string) {
 	m.filterState = Filtering
 	m.FilterInput.SetValue(filter)
-	cmd := filterItems(*m)
+	cmd := m.filterItems()
 	msg := cmd()
-	fmm, _ := msg.(FilterMatchesMsg)
-	m.filteredItems = filteredItems(fmm)
+	fmm, _ := msg.(filterMatchesMsg)
+	m.filteredItems = fmm.matches
+	m.sortFilteredItems()
+	m.filterQuery, m.refinable = fmm.query, true
 	m.filterState = FilterApplied
 	m.GoToStart()
 	m.FilterInput.CursorEnd()
@@ -376,14 +524,27 @@ func (m Model) Items() []Item {
 	return m.items
 }
 
-// SetItems sets the items available in the list. This returns a command.
+// SetItems sets the items available in the list, removing the data source if
+// one was set. This returns a command.
 func (m *Model) SetItems(i []Item) tea.Cmd {
 	var cmd tea.Cmd
+	if m.dataSource != nil {
+		m.dataSource = nil
+		m.dataGen++
+		m.fetching = nil
+		m.fetchFailed = nil
+	}
 	m.items = i
+	m.grouped = anyGrouped(i)
+	m.actionable = anyActionItem(i)
+	m.unsortedRank = nil
+	m.dropPositionalSelection()
+	m.invalidateFilter()
+	m.sortItems()
 
 	if m.filterState != Unfiltered {
 		m.filteredItems = nil
-		cmd = filterItems(*m)
+		cmd = m.filterItems()
 	}
 
 	m.updatePagination()
@@ -393,8 +554,9 @@ func (m *Model) SetItems(i []Item) tea.Cmd {
 
 // Select selects the given index of the list and goes to its respective page.
 func (m *Model) Select(index int) {
-	m.Paginator.Page = index / m.Paginator.PerPage
-	m.cursor = index % m.Paginator.PerPage
+	m.Paginator.Page = m.pageOf(index)
+	m.cursor = index - m.pageStart(m.Paginator.Page)
+	m.scrollToCursor()
 }
 
 // ResetSelected resets the selected item to the first item in the first page of the list.
@@ -411,9 +573,13 @@ func (m *Model) ResetFilter() {
 func (m *Model) SetItem(index int, item Item) tea.Cmd {
 	var cmd tea.Cmd
 	m.items[index] = item
+	m.grouped = m.grouped || groupOf(item) != ""
+	m.actionable = m.actionable || anyActionItem([]Item{item})
+	m.invalidateFilter()
+	m.sortItems()
 
 	if m.filterState != Unfiltered {
-		cmd = filterItems(*m)
+		cmd = m.filterItems()
 	}
 
 	m.updatePagination()
@@ -424,10 +590,16 @@ func (m *Model) SetItem(index int, item Item) tea.Cmd {
 // the item will be appended. This returns a command.
 func (m *Model) InsertItem(index int, item Item) tea.Cmd {
 	var cmd tea.Cmd
+	m.shiftSelection(min(max(0, index), len(m.items)), 1)
+	m.insertRank(index)
 	m.items = insertItemIntoSlice(m.items, item, index)
+	m.grouped = m.grouped || groupOf(item) != ""
+	m.actionable = m.actionable || anyActionItem([]Item{item})
+	m.invalidateFilter()
+	m.sortItems()
 
 	if m.filterState != Unfiltered {
-		cmd = filterItems(*m)
+		cmd = m.filterItems()
 	}
 
 	m.updatePagination()
@@ -439,7 +611,12 @@ func (m *Model) InsertItem(index int, item Item) tea.Cmd {
 // this will be a no-op. O(n) complexity, which probably won't matter in the
 // case of a TUI.
 func (m *Model) RemoveItem(index int) {
+	if index >= 0 && index < len(m.items) {
+		m.shiftSelection(index, -1)
+		m.removeRank(index)
+	}
 	m.items = removeItemFromSlice(m.items, index)
+	m.invalidateFilter()
 	if m.filterState != Unfiltered {
 		m.filteredItems = removeFilterMatchFromSlice(m.filteredItems, index)
 		if len(m.filteredItems) == 0 {
@@ -463,8 +640,19 @@ func (m Model) VisibleItems() []Item {
 	return m.items
 }
 
-// SelectedItem returns the current selected item in the list.
+// SelectedItem returns the current selected item in the list. It returns nil
+// while the cursor is on the header of a collapsed group.
 func (m Model) SelectedItem() Item {
+	if m.onCollapsedHeader() {
+		return nil
+	}
+	return m.cursorItem()
+}
+
+// cursorItem returns the item under the cursor. Unlike SelectedItem, that
+// includes the first item of a collapsed group, which stands in for its
+// header.
+func (m Model) cursorItem() Item {
 	i := m.Index()
 
 	items := m.VisibleItems()
@@ -491,7 +679,7 @@ func (m Model) MatchesForItem(index int) []int {
 // Using this value with SetItem() might be incorrect, consider using
 // GlobalIndex() instead.
 func (m Model) Index() int {
-	return m.Paginator.Page*m.Paginator.PerPage + m.cursor
+	return m.pageStart(m.Paginator.Page) + m.cursor
 }
 
 // GlobalIndex returns the index of the currently selected item as it is stored
@@ -514,6 +702,25 @@ func (m Model) Cursor() int {
 // CursorUp moves the cursor up. This can also move the state to the previous
 // page.
 func (m *Model) CursorUp() {
+	if m.isGrid() {
+		m.gridCursorUp()
+		return
+	}
+	defer m.scrollToCursor()
+
+	// Items in collapsed groups are skipped until the group's header.
+	items := m.VisibleItems()
+	for {
+		prev := m.Index()
+		m.cursorUp()
+		index := m.Index()
+		if index == prev || index >= len(items) || !m.isHidden(items, index) {
+			return
+		}
+	}
+}
+
+func (m *Model) cursorUp() {
 	m.cursor--
 
 	// If we're at the start, stop
@@ -540,6 +747,31 @@ func (m *Model) CursorUp() {
 // CursorDown moves the cursor down. This can also advance the state to the
 // next page.
 func (m *Model) CursorDown() {
+	if m.isGrid() {
+		m.gridCursorDown()
+		return
+	}
+	defer m.scrollToCursor()
+
+	// Items in collapsed groups are skipped.
+	items := m.VisibleItems()
+	start := m.Index()
+	for {
+		prev := m.Index()
+		m.cursorDown()
+		index := m.Index()
+		if index >= len(items) || !m.isHidden(items, index) {
+			return
+		}
+		if index == prev {
+			// Only hidden items are left.
+			m.Select(start)
+			return
+		}
+	}
+}
+
+func (m *Model) cursorDown() {
 	maxCursorIndex := m.maxCursorIndex()
 
 	m.cursor++
@@ -568,28 +800,105 @@ func (m *Model) CursorDown() {
 func (m *Model) GoToStart() {
 	m.Paginator.Page = 0
 	m.cursor = 0
+	m.scrollToCursor()
 }
 
 // GoToEnd moves to the last page, and last item on the last page.
 func (m *Model) GoToEnd() {
 	m.Paginator.Page = max(0, m.Paginator.TotalPages-1)
 	m.cursor = m.maxCursorIndex()
+	m.skipCollapsed()
+	m.scrollToCursor()
 }
 
-// PrevPage moves to the previous page, if available.
+// PrevPage moves to the previous page, if available. With continuous
+// scrolling, it moves the cursor up by a screenful of items.
 func (m *Model) PrevPage() {
+	if m.scrolling() {
+		m.cursor = clamp(m.cursor-m.screenful(), 0, m.maxCursorIndex())
+		m.scrollToCursor()
+		return
+	}
 	m.Paginator.PrevPage()
 	m.cursor = clamp(m.cursor, 0, m.maxCursorIndex())
+	m.skipCollapsed()
 }
 
-// NextPage moves to the next page, if available.
+// NextPage moves to the next page, if available. With continuous scrolling,
+// it moves the cursor down by a screenful of items.
 func (m *Model) NextPage() {
+	if m.scrolling() {
+		m.cursor = clamp(m.cursor+m.screenful(), 0, m.maxCursorIndex())
+		m.scrollToCursor()
+		return
+	}
 	m.Paginator.NextPage()
 	m.cursor = clamp(m.cursor, 0, m.maxCursorIndex())
+	m.skipCollapsed()
 }
 
 func (m *Model) maxCursorIndex() int {
-	return max(0, m.Paginator.ItemsOnPage(len(m.VisibleItems()))-1)
+	start, end := m.pageBounds(len(m.VisibleItems()))
+	return max(0, end-start-1)
+}
+
+// pageStart returns the index of the first item on the given page.
+func (m Model) pageStart(page int) int {
+	if m.pageStarts == nil {
+		return page * m.Paginator.PerPage
+	}
+	if page < 0 || len(m.pageStarts) == 0 {
+		return 0
+	}
+	if page >= len(m.pageStarts) {
+		return m.pageStarts[len(m.pageStarts)-1]
+	}
+	return m.pageStarts[page]
+}
+
+// pageOf returns the page the item at the given index is on.
+func (m Model) pageOf(index int) int {
+	if m.pageStarts == nil {
+		return index / m.Paginator.PerPage
+	}
+	return max(0, sort.SearchInts(m.pageStarts, index+1)-1)
+}
+
+// pageBounds returns the bounds of the current page given the total number of
+// visible items.
+func (m Model) pageBounds(total int) (start, end int) {
+	if m.pageStarts == nil {
+		return m.Paginator.GetSliceBounds(total)
+	}
+	start = min(m.pageStart(m.Paginator.Page), total)
+	end = total
+	if m.Paginator.Page+1 < len(m.pageStarts) {
+		end = min(m.pageStarts[m.Paginator.Page+1], total)
+	}
+	return start, end
+}
+
+// paginateByHeight splits the visible items into pages, filling each page
+// with as many items as fit in the available height.
+func (m Model) paginateByHeight(availHeight int) []int {
+	starts := []int{0}
+	used := 0
+	items := m.VisibleItems()
+	for i := range items {
+		h := m.rowHeight(items, i)
+		if h == 0 {
+			continue
+		}
+		if used > 0 && used+m.delegate.Spacing()+h > availHeight {
+			starts = append(starts, i)
+			used = 0
+		}
+		if used > 0 {
+			used += m.delegate.Spacing()
+		}
+		used += h
+	}
+	return starts
 }
 
 // FilterState returns the current filter state.
@@ -711,6 +1020,8 @@ func (m *Model) resetFiltering() {
 	m.filterState = Unfiltered
 	m.FilterInput.Reset()
 	m.filteredItems = nil
+	m.cancelFilter()
+	m.invalidateFilter()
 	m.updatePagination()
 	m.updateKeybindings()
 }
@@ -719,7 +1030,8 @@ func (m Model) itemsAsFilterItems() filteredItems {
 	fi := make([]filteredItem, len(m.items))
 	for i, item := range m.items {
 		fi[i] = filteredItem{
-			item: item,
+			index: i,
+			item:  item,
 		}
 	}
 	return fi
@@ -731,12 +1043,28 @@ func (m *Model) updateKeybindings() {
 	case Filtering:
 		m.KeyMap.CursorUp.SetEnabled(false)
 		m.KeyMap.CursorDown.SetEnabled(false)
+		m.KeyMap.CursorLeft.SetEnabled(false)
+		m.KeyMap.CursorRight.SetEnabled(false)
 		m.KeyMap.NextPage.SetEnabled(false)
 		m.KeyMap.PrevPage.SetEnabled(false)
 		m.KeyMap.GoToStart.SetEnabled(false)
 		m.KeyMap.GoToEnd.SetEnabled(false)
 		m.KeyMap.Filter.SetEnabled(false)
 		m.KeyMap.ClearFilter.SetEnabled(false)
+		m.KeyMap.ToggleSelect.SetEnabled(false)
+		m.KeyMap.SelectAll.SetEnabled(false)
+		m.KeyMap.SelectNone.SetEnabled(false)
+		m.KeyMap.InvertSelection.SetEnabled(false)
+		m.KeyMap.CycleSortOrder.SetEnabled(false)
+		m.KeyMap.ToggleSortDirection.SetEnabled(false)
+		m.KeyMap.ToggleGroup.SetEnabled(false)
+		m.KeyMap.MoveItemUp.SetEnabled(false)
+		m.KeyMap.MoveItemDown.SetEnabled(false)
+		m.KeyMap.MoveItemToTop.SetEnabled(false)
+		m.KeyMap.MoveItemToBottom.SetEnabled(false)
+		m.KeyMap.ShowActions.SetEnabled(false)
+		m.KeyMap.ChooseAction.SetEnabled(false)
+		m.KeyMap.CloseActions.SetEnabled(false)
 		m.KeyMap.CancelWhileFiltering.SetEnabled(true)
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
 		m.KeyMap.Quit.SetEnabled(false)
@@ -748,6 +1076,11 @@ func (m *Model) updateKeybindings() {
 		m.KeyMap.CursorUp.SetEnabled(hasItems)
 		m.KeyMap.CursorDown.SetEnabled(hasItems)
 
+		// In a grid, left and right move the cursor rather than the page.
+		grid := m.isGrid()
+		m.KeyMap.CursorLeft.SetEnabled(grid && hasItems)
+		m.KeyMap.CursorRight.SetEnabled(grid && hasItems)
+
 		hasPages := m.Paginator.TotalPages > 1
 		m.KeyMap.NextPage.SetEnabled(hasPages)
 		m.KeyMap.PrevPage.SetEnabled(hasPages)
@@ -757,6 +1090,29 @@ func (m *Model) updateKeybindings() {
 
 		m.KeyMap.Filter.SetEnabled(m.filteringEnabled && hasItems)
 		m.KeyMap.ClearFilter.SetEnabled(m.filterState == FilterApplied)
+
+		canSelect := m.multiSelect && hasItems
+		m.KeyMap.ToggleSelect.SetEnabled(canSelect)
+		m.KeyMap.SelectAll.SetEnabled(canSelect)
+		m.KeyMap.SelectNone.SetEnabled(canSelect)
+		m.KeyMap.InvertSelection.SetEnabled(canSelect)
+
+		_, sorted := m.SortOrder()
+		m.KeyMap.CycleSortOrder.SetEnabled(len(m.sortOrders) > 0 && hasItems)
+		m.KeyMap.ToggleSortDirection.SetEnabled(sorted && hasItems)
+
+		m.KeyMap.ToggleGroup.SetEnabled(m.filterState == Unfiltered && m.hasGroups())
+
+		canReorder := m.canReorder()
+		m.KeyMap.MoveItemUp.SetEnabled(canReorder)
+		m.KeyMap.MoveItemDown.SetEnabled(canReorder)
+		m.KeyMap.MoveItemToTop.SetEnabled(canReorder)
+		m.KeyMap.MoveItemToBottom.SetEnabled(canReorder)
+
+		m.KeyMap.ShowActions.SetEnabled(!m.actionMenu && hasItems && m.hasActions())
+		m.KeyMap.ChooseAction.SetEnabled(m.actionMenu)
+		m.KeyMap.CloseActions.SetEnabled(m.actionMenu)
+
 		m.KeyMap.CancelWhileFiltering.SetEnabled(false)
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(false)
 		m.KeyMap.Quit.SetEnabled(!m.disableQuitKeybindings)
@@ -790,17 +1146,34 @@ func (m *Model) updatePagination() {
 		availHeight -= lipgloss.Height(m.helpView())
 	}
 
-	m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
-
-	if pages := len(m.VisibleItems()); pages < 1 {
-		m.Paginator.SetTotalPages(1)
+	m.itemsHeight = availHeight
+
+	if columns := m.gridColumns(); columns > 0 {
+		m.pageStarts = nil
+		rows := max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
+		m.Paginator.PerPage = rows * columns
+		m.Paginator.SetTotalPages(max(1, len(m.VisibleItems())))
+	} else if m.scrolling() {
+		// Everything is on a single page, the view scrolls instead.
+		m.pageStarts = nil
+		m.Paginator.PerPage = max(1, len(m.VisibleItems()))
+		m.Paginator.SetTotalPages(m.Paginator.PerPage)
+	} else if _, ok := m.delegate.(VariableHeightDelegate); ok || m.hasGroups() {
+		m.pageStarts = m.paginateByHeight(availHeight)
+		m.Paginator.TotalPages = len(m.pageStarts)
 	} else {
-		m.Paginator.SetTotalPages(pages)
+		m.pageStarts = nil
+		m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
+
+		if pages := len(m.VisibleItems()); pages < 1 {
+			m.Paginator.SetTotalPages(1)
+		} else {
+			m.Paginator.SetTotalPages(pages)
+		}
 	}
 
 	// Restore index
-	m.Paginator.Page = index / m.Paginator.PerPage
-	m.cursor = index % m.Paginator.PerPage
+	m.Select(index)
 
 	// Make sure the page stays in bounds
 	if m.Paginator.Page >= m.Paginator.TotalPages-1 {
@@ -825,10 +1198,35 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
+	case tea.BackgroundColorMsg:
+		m.SetDarkMode(msg.IsDark())
+
 	case FilterMatchesMsg:
 		m.filteredItems = filteredItems(msg)
+		m.refinable = false
 		return m, nil
 
+	case filterMatchesMsg:
+		if msg.id != m.id || msg.tag != m.filterTag {
+			// Stale results for a superseded query.
+			return m, nil
+		}
+		m.filteredItems = msg.matches
+		m.filterQuery, m.refinable = msg.query, true
+		m.sortFilteredItems()
+		m.filterCancel = nil
+		m.updatePagination()
+		return m, nil
+
+	case filterDebounceMsg:
+		if msg.id != m.id || msg.tag != m.filterTag {
+			return m, nil
+		}
+		return m, m.filterItems()
+
+	case fetchedMsg:
+		return m, m.handleFetched(msg)
+
 	case spinner.TickMsg:
 		newSpinnerModel, cmd := m.spinner.Update(msg)
 		m.spinner = newSpinnerModel
@@ -845,13 +1243,33 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	} else {
 		cmds = append(cmds, m.handleBrowsing(msg))
 	}
+	cmds = append(cmds, m.fetchVisible())
 
 	return m, tea.Batch(cmds...)
 }
 
 // Updates for when a user is browsing the list.
 func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	if m.actionMenu {
+		switch msg.(type) {
+		case tea.KeyPressMsg, tea.MouseMsg:
+			return m.handleActionMenu(msg)
+		}
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		if action, ok := m.matchAction(msg); ok {
+			return m.chooseAction(action)
+		}
+	}
+
 	switch msg := msg.(type) {
+	case tea.MouseClickMsg, tea.MouseWheelMsg, tea.MouseMotionMsg, tea.MouseReleaseMsg:
+		if m.MouseEnabled {
+			cmds = append(cmds, m.handleMouse(msg))
+		}
+
 	case tea.KeyPressMsg:
 		switch {
 		// Note: we match clear filter before quit because, by default, they're
@@ -868,11 +1286,17 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, m.KeyMap.CursorDown):
 			m.CursorDown()
 
+		case key.Matches(msg, m.KeyMap.CursorLeft):
+			m.CursorLeft()
+
+		case key.Matches(msg, m.KeyMap.CursorRight):
+			m.CursorRight()
+
 		case key.Matches(msg, m.KeyMap.PrevPage):
-			m.Paginator.PrevPage()
+			m.PrevPage()
 
 		case key.Matches(msg, m.KeyMap.NextPage):
-			m.Paginator.NextPage()
+			m.NextPage()
 
 		case key.Matches(msg, m.KeyMap.GoToStart):
 			m.GoToStart()
@@ -880,11 +1304,51 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, m.KeyMap.GoToEnd):
 			m.GoToEnd()
 
+		case key.Matches(msg, m.KeyMap.ToggleSelect):
+			// Group headers can't be selected.
+			if !m.onCollapsedHeader() {
+				m.ToggleSelected(m.Index())
+			}
+
+		case key.Matches(msg, m.KeyMap.SelectAll):
+			m.SelectAll()
+
+		case key.Matches(msg, m.KeyMap.SelectNone):
+			m.SelectNone()
+
+		case key.Matches(msg, m.KeyMap.InvertSelection):
+			m.InvertSelection()
+
+		case key.Matches(msg, m.KeyMap.CycleSortOrder):
+			cmds = append(cmds, m.CycleSortOrder())
+
+		case key.Matches(msg, m.KeyMap.ToggleSortDirection):
+			cmds = append(cmds, m.ToggleSortDirection())
+
+		case key.Matches(msg, m.KeyMap.ToggleGroup):
+			m.ToggleGroup()
+
+		case key.Matches(msg, m.KeyMap.ShowActions):
+			m.ShowActions()
+
+		case key.Matches(msg, m.KeyMap.MoveItemUp):
+			cmds = append(cmds, m.moveSelected(m.Index()-1))
+
+		case key.Matches(msg, m.KeyMap.MoveItemDown):
+			cmds = append(cmds, m.moveSelected(m.Index()+1))
+
+		case key.Matches(msg, m.KeyMap.MoveItemToTop):
+			cmds = append(cmds, m.moveSelected(0))
+
+		case key.Matches(msg, m.KeyMap.MoveItemToBottom):
+			cmds = append(cmds, m.moveSelected(len(m.items)-1))
+
 		case key.Matches(msg, m.KeyMap.Filter):
 			m.hideStatusMessage()
 			if m.FilterInput.Value() == "" {
 				// Populate filter with all items only if the filter is empty.
 				m.filteredItems = m.itemsAsFilterItems()
+				m.invalidateFilter()
 			}
 			m.GoToStart()
 			m.filterState = Filtering
@@ -901,10 +1365,12 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		}
 	}
 
-	cmd := m.delegate.Update(msg, m)
+	cmds = append(cmds, m.delegate.Update(msg, m))
 	m.cursor = clamp(m.cursor, 0, m.maxCursorIndex())
+	m.skipCollapsed()
+	m.scrollToCursor()
 
-	return cmd
+	return tea.Batch(cmds...)
 }
 
 // Updates for when a user is in the filter editing interface.
@@ -952,7 +1418,7 @@ func (m *Model) handleFiltering(msg tea.Msg) tea.Cmd {
 
 	// If the filtering input has changed, request updated filtering
 	if filterChanged {
-		cmds = append(cmds, filterItems(*m))
+		cmds = append(cmds, m.requestFilter())
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
 	}
 
@@ -965,9 +1431,21 @@ func (m *Model) handleFiltering(msg tea.Msg) tea.Cmd {
 // ShortHelp returns bindings to show in the abbreviated help view. It's part
 // of the help.KeyMap interface.
 func (m Model) ShortHelp() []key.Binding {
+	if m.actionMenu {
+		return []key.Binding{
+			m.KeyMap.CursorUp,
+			m.KeyMap.CursorDown,
+			m.KeyMap.ChooseAction,
+			m.KeyMap.CloseActions,
+		}
+	}
+
 	kb := []key.Binding{
 		m.KeyMap.CursorUp,
 		m.KeyMap.CursorDown,
+		m.KeyMap.ToggleSelect,
+		m.KeyMap.CycleSortOrder,
+		m.KeyMap.ShowActions,
 	}
 
 	filtering := m.filterState == Filtering
@@ -1003,6 +1481,8 @@ func (m Model) FullHelp() [][]key.Binding {
 	kb := [][]key.Binding{{
 		m.KeyMap.CursorUp,
 		m.KeyMap.CursorDown,
+		m.KeyMap.CursorLeft,
+		m.KeyMap.CursorRight,
 		m.KeyMap.NextPage,
 		m.KeyMap.PrevPage,
 		m.KeyMap.GoToStart,
@@ -1019,6 +1499,39 @@ func (m Model) FullHelp() [][]key.Binding {
 		}
 	}
 
+	if m.multiSelect && !filtering {
+		kb = append(kb, []key.Binding{
+			m.KeyMap.ToggleSelect,
+			m.KeyMap.SelectAll,
+			m.KeyMap.SelectNone,
+			m.KeyMap.InvertSelection,
+		})
+	}
+
+	if len(m.sortOrders) > 0 && !filtering {
+		kb = append(kb, []key.Binding{
+			m.KeyMap.CycleSortOrder,
+			m.KeyMap.ToggleSortDirection,
+		})
+	}
+
+	if m.reorderingEnabled && !filtering {
+		kb = append(kb, []key.Binding{
+			m.KeyMap.MoveItemUp,
+			m.KeyMap.MoveItemDown,
+			m.KeyMap.MoveItemToTop,
+			m.KeyMap.MoveItemToBottom,
+		})
+	}
+
+	if m.KeyMap.ShowActions.Enabled() && !filtering {
+		kb = append(kb, append([]key.Binding{m.KeyMap.ShowActions}, m.actionsHelp()...))
+	}
+
+	if m.hasGroups() && !filtering {
+		kb = append(kb, []key.Binding{m.KeyMap.ToggleGroup})
+	}
+
 	listLevelBindings := []key.Binding{
 		m.KeyMap.Filter,
 		m.KeyMap.ClearFilter,
@@ -1080,7 +1593,15 @@ func (m Model) View() string {
 		sections = append(sections, help)
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+	view := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	if m.actionMenu {
+		x, y := m.actionMenuPosition()
+		view = lipgloss.NewCompositor(
+			lipgloss.NewLayer(view),
+			lipgloss.NewLayer(m.actionMenuView()).X(x).Y(y).Z(1),
+		).Render()
+	}
+	return view
 }
 
 func (m Model) titleView() string {
@@ -1137,6 +1658,20 @@ func (m Model) statusView() string {
 	totalItems := len(m.items)
 	visibleItems := len(m.VisibleItems())
 
+	// Items still to be loaded from a data source aren't counted when
+	// filtering, and when their number isn't known yet.
+	var more string
+	if m.dataSource != nil {
+		switch {
+		case m.filterState != Unfiltered:
+			totalItems = m.loadedItems()
+		case !m.dataComplete:
+			totalItems = m.loadedItems()
+			visibleItems = totalItems
+			more = "+"
+		}
+	}
+
 	var itemName string
 	if visibleItems != 1 {
 		itemName = m.itemNamePlural
@@ -1144,7 +1679,7 @@ func (m Model) statusView() string {
 		itemName = m.itemNameSingular
 	}
 
-	itemsDisplay := fmt.Sprintf("%d %s", visibleItems, itemName)
+	itemsDisplay := fmt.Sprintf("%d%s %s", visibleItems, more, itemName)
 
 	if m.filterState == Filtering { //nolint:nestif
 		// Filter results
@@ -1175,21 +1710,37 @@ func (m Model) statusView() string {
 		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d filtered", numFiltered))
 	}
 
+	if order, ok := m.SortOrder(); ok && len(m.items) > 0 {
+		arrow := "↑"
+		if m.sortDescending {
+			arrow = "↓"
+		}
+		status += m.Styles.DividerDot.String()
+		status += m.Styles.StatusBarSortOrder.Render("sorted by " + order.Name + " " + arrow)
+	}
+
 	return m.Styles.StatusBar.Render(status)
 }
 
 func (m Model) paginationView() string {
-	if m.Paginator.TotalPages < 2 { //nolint:mnd
-		return ""
-	}
+	var s string
+	if m.scrolling() {
+		if s = m.scrollView(); s == "" {
+			return ""
+		}
+	} else {
+		if m.Paginator.TotalPages < 2 { //nolint:mnd
+			return ""
+		}
 
-	s := m.Paginator.View()
+		s = m.Paginator.View()
 
-	// If the dot pagination is wider than the width of the window
-	// use the arabic paginator.
-	if ansi.StringWidth(s) > m.width {
-		m.Paginator.Type = paginator.Arabic
-		s = m.Styles.ArabicPagination.Render(m.Paginator.View())
+		// If the dot pagination is wider than the width of the window
+		// use the arabic paginator.
+		if ansi.StringWidth(s) > m.width {
+			m.Paginator.Type = paginator.Arabic
+			s = m.Styles.ArabicPagination.Render(m.Paginator.View())
+		}
 	}
 
 	style := m.Styles.PaginationStyle
@@ -1213,18 +1764,49 @@ func (m Model) populatedView() string {
 		return m.Styles.NoItems.Render("No " + m.itemNamePlural + ".")
 	}
 
+	if m.isGrid() {
+		return m.gridView()
+	}
+
 	if len(items) > 0 {
-		start, end := m.Paginator.GetSliceBounds(len(items))
+		start, end := m.pageBounds(len(items))
+		if m.scrolling() {
+			start, end = m.visibleRange(items)
+		}
 		docs := items[start:end]
 
+		rendered := false
 		for i, item := range docs {
-			m.delegate.Render(&b, m, i+start, item)
-			if i != len(docs)-1 {
+			index := i + start
+			if m.isHidden(items, index) {
+				continue
+			}
+			if rendered {
 				fmt.Fprint(&b, strings.Repeat("\n", m.delegate.Spacing()+1))
 			}
+			rendered = true
+
+			if item == nil {
+				b.WriteString(m.placeholderView(index))
+				continue
+			}
+			if m.isGroupHeader(items, index) {
+				b.WriteString(m.groupHeaderView(items, index))
+				if m.isCollapsed(items, index) {
+					continue
+				}
+				b.WriteString("\n")
+			}
+			m.delegate.Render(&b, m, index, item)
 		}
 	}
 
+	// Pages of variable height items, and the scrolling window, are padded to
+	// the available height by View.
+	if m.pageStarts != nil || m.scrolling() {
+		return b.String()
+	}
+
 	// If there aren't enough items to fill up this page (always the last page)
 	// then we need to add some newlines to fill up the space where items would
 	// have been.
@@ -1248,32 +1830,6 @@ func (m Model) spinnerView() string {
 	return m.spinner.View()
 }
 
-func filterItems(m Model) tea.Cmd {
-	return func() tea.Msg {
-		if m.FilterInput.Value() == "" || m.filterState == Unfiltered {
-			return FilterMatchesMsg(m.itemsAsFilterItems()) // return nothing
-		}
-
-		items := m.items
-		targets := make([]string, len(items))
-
-		for i, t := range items {
-			targets[i] = t.FilterValue()
-		}
-
-		filterMatches := []filteredItem{}
-		for _, r := range m.Filter(m.FilterInput.Value(), targets) {
-			filterMatches = append(filterMatches, filteredItem{
-				index:   r.Index,
-				item:    items[r.Index],
-				matches: r.MatchedIndexes,
-			})
-		}
-
-		return FilterMatchesMsg(filterMatches)
-	}
-}
-
 func insertItemIntoSlice(items []Item, item Item, index int) []Item {
 	if items == nil {
 		return []Item{item}
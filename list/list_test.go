@@ -1,14 +1,24 @@
 package list
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image/color"
 	"io"
 	"reflect"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 )
 
 type item string
@@ -135,3 +145,1142 @@ func TestSetFilterState(t *testing.T) {
 		t.Fatalf("Error: expected view to contain '%s'", expected)
 	}
 }
+
+func TestMultiSelect(t *testing.T) {
+	tc := []Item{item("foo"), item("bar"), item("baz")}
+
+	list := New(tc, itemDelegate{}, 10, 10)
+	space := tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+
+	list, _ = list.Update(space)
+	if len(list.SelectedItems()) != 0 {
+		t.Fatal("expected selection to be disabled by default")
+	}
+
+	list.SetMultiSelect(true)
+	list, _ = list.Update(space)
+	list.CursorDown()
+	list, _ = list.Update(space)
+	if got, want := list.SelectedItems(), []Item{item("foo"), item("bar")}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v to be selected, got %v", want, got)
+	}
+
+	// Selection survives filtering and applies to visible items only.
+	list.SetFilterText("ba")
+	if !list.IsSelected(0) || list.IsSelected(1) {
+		t.Fatal("expected bar to be selected and baz not to be")
+	}
+	list.InvertSelection()
+	if got, want := list.SelectedItems(), []Item{item("foo"), item("baz")}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v to be selected, got %v", want, got)
+	}
+
+	list.ResetFilter()
+	list.RemoveItem(0)
+	if got, want := list.SelectedItems(), []Item{item("baz")}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v to be selected after removal, got %v", want, got)
+	}
+
+	list.SelectAll()
+	if len(list.SelectedItems()) != 2 {
+		t.Fatalf("expected all items to be selected, got %v", list.SelectedItems())
+	}
+	list.SetMultiSelect(false)
+	if len(list.SelectedItems()) != 0 {
+		t.Fatal("expected disabling multi-select to clear the selection")
+	}
+}
+
+type identifiedItem struct{ id, title string }
+
+func (i identifiedItem) FilterValue() string { return i.title }
+func (i identifiedItem) ID() string          { return i.id }
+func (i identifiedItem) Title() string       { return i.title }
+func (i identifiedItem) Description() string { return "" }
+
+func TestMultiSelectIdentifiableItems(t *testing.T) {
+	list := New([]Item{
+		identifiedItem{"1", "foo"},
+		identifiedItem{"2", "bar"},
+	}, NewDefaultDelegate(), 20, 20)
+	list.SetMultiSelect(true)
+	list.SetSelected(1, true)
+
+	list.SetItems([]Item{
+		identifiedItem{"2", "bar (edited)"},
+		identifiedItem{"3", "baz"},
+	})
+	if got, want := list.SelectedItems(), []Item{identifiedItem{"2", "bar (edited)"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v to stay selected, got %v", want, got)
+	}
+
+	if view := ansi.Strip(list.View()); !strings.Contains(view, "✓ bar (edited)") {
+		t.Fatalf("expected a checkmark next to the selected item, got:\n%s", view)
+	}
+}
+
+// multilineDelegate renders each item on as many lines as it has words.
+type multilineDelegate struct{ itemDelegate }
+
+func (d multilineDelegate) ItemHeight(_ int, listItem Item) int {
+	return len(strings.Fields(listItem.FilterValue()))
+}
+
+func (d multilineDelegate) Render(w io.Writer, m Model, index int, listItem Item) {
+	fmt.Fprint(w, strings.Join(strings.Fields(listItem.FilterValue()), "\n"))
+}
+
+func TestVariableHeightItems(t *testing.T) {
+	items := []Item{
+		item("a1"),
+		item("b1 b2 b3"),
+		item("c1 c2"),
+		item("d1"),
+		item("e1 e2 e3 e4"),
+	}
+	list := New(items, multilineDelegate{}, 20, 4)
+	list.SetShowTitle(false)
+	list.SetShowFilter(false)
+	list.SetShowStatusBar(false)
+	list.SetShowPagination(false)
+	list.SetShowHelp(false)
+
+	// Pages are filled by lines: [a1, b1..b3], [c1..c2, d1], [e1..e4].
+	if list.Paginator.TotalPages != 3 {
+		t.Fatalf("expected 3 pages, got %d", list.Paginator.TotalPages)
+	}
+
+	for range 3 {
+		list.CursorDown()
+	}
+	if list.Index() != 3 || list.Paginator.Page != 1 || list.Cursor() != 1 {
+		t.Fatalf("expected item 3 on page 1 at cursor 1, got index %d page %d cursor %d",
+			list.Index(), list.Paginator.Page, list.Cursor())
+	}
+	if view := list.View(); !strings.HasPrefix(view, "c1\nc2\nd1") {
+		t.Fatalf("expected page 1 to be rendered, got %q", view)
+	}
+
+	list.Select(4)
+	if list.Paginator.Page != 2 || list.Cursor() != 0 {
+		t.Fatalf("expected item 4 alone on the last page, got page %d cursor %d", list.Paginator.Page, list.Cursor())
+	}
+	list.CursorDown()
+	if list.Index() != 4 {
+		t.Fatalf("expected cursor to stay on the last item, got %d", list.Index())
+	}
+}
+
+func TestContinuousScrolling(t *testing.T) {
+	var items []Item
+	for i := range 10 {
+		items = append(items, item(fmt.Sprint(i)))
+	}
+	list := New(items, multilineDelegate{}, 20, 5)
+	list.SetShowTitle(false)
+	list.SetShowFilter(false)
+	list.SetShowStatusBar(false)
+	list.SetShowPagination(false)
+	list.SetShowHelp(false)
+	list.SetContinuousScrolling(true)
+	list.SetScrollOff(1)
+
+	lines := func() []string {
+		lines := strings.Split(ansi.Strip(list.View()), "\n")
+		for i := range lines {
+			lines[i] = strings.TrimSpace(lines[i])
+		}
+		return lines
+	}
+
+	for range 3 {
+		list.CursorDown()
+	}
+	if got := lines(); got[0] != "0" || got[4] != "4" {
+		t.Fatalf("expected window to stay put, got %q", got)
+	}
+
+	// Moving further slides the window by one item to keep the margin.
+	list.CursorDown()
+	if got := lines(); got[0] != "1" || got[4] != "5" {
+		t.Fatalf("expected window to slide by one item, got %q", got)
+	}
+	if list.Paginator.Page != 0 || list.Cursor() != 4 {
+		t.Fatalf("expected cursor 4 on the only page, got page %d cursor %d", list.Paginator.Page, list.Cursor())
+	}
+
+	list.NextPage()
+	if list.Index() != 9 {
+		t.Fatalf("expected next page to move the cursor by a screenful, got %d", list.Index())
+	}
+	if got := lines(); got[0] != "5" || got[4] != "9" {
+		t.Fatalf("expected window to end at the last item, got %q", got)
+	}
+
+	list.SetShowPagination(true)
+	if view := ansi.Strip(list.View()); !strings.Contains(view, "10/10") {
+		t.Fatalf("expected scroll position indicator, got %q", view)
+	}
+
+	list.GoToStart()
+	if got := lines(); got[0] != "0" {
+		t.Fatalf("expected window to scroll back to the top, got %q", got)
+	}
+}
+
+// filterMsgs runs cmd and returns the filtering messages it produces.
+func filterMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, cmd := range msg {
+			msgs = append(msgs, filterMsgs(cmd)...)
+		}
+		return msgs
+	case filterMatchesMsg, filterDebounceMsg:
+		return []tea.Msg{msg}
+	}
+	return nil
+}
+
+// typeFilter types s into the filter input and returns the filtering messages
+// produced along the way.
+func typeFilter(list Model, s string) (Model, []tea.Msg) {
+	// Don't wait for the cursor to blink when running commands.
+	styles := list.FilterInput.Styles()
+	styles.Cursor.Blink = false
+	list.FilterInput.SetStyles(styles)
+
+	var msgs []tea.Msg
+	for _, r := range s {
+		var cmd tea.Cmd
+		list, cmd = list.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		msgs = append(msgs, filterMsgs(cmd)...)
+	}
+	return list, msgs
+}
+
+func TestFilterDiscardsStaleResults(t *testing.T) {
+	list := New([]Item{item("foo"), item("bar"), item("baz")}, itemDelegate{}, 10, 10)
+	list, _ = list.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+
+	list, msgs := typeFilter(list, "ba")
+	if len(msgs) != 2 {
+		t.Fatalf("expected a filter result per keystroke, got %d", len(msgs))
+	}
+
+	// Results arrive out of order: the result for "b" must not override the
+	// result for "ba".
+	list, _ = list.Update(msgs[1])
+	list, _ = list.Update(msgs[0])
+	if got := list.VisibleItems(); !slices.Equal(got, []Item{item("bar"), item("baz")}) {
+		t.Fatalf("expected results for the latest query, got %v", got)
+	}
+}
+
+func TestFilterDebounce(t *testing.T) {
+	list := New([]Item{item("foo"), item("bar")}, itemDelegate{}, 10, 10)
+	list.FilterDebounce = time.Millisecond
+	list, _ = list.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+
+	list, msgs := typeFilter(list, "fo")
+	for _, msg := range msgs {
+		if _, ok := msg.(filterDebounceMsg); !ok {
+			t.Fatalf("expected filtering to be debounced, got %T", msg)
+		}
+	}
+
+	// Only the last keystroke triggers filtering.
+	list, cmd := list.Update(msgs[0])
+	if cmd != nil {
+		t.Fatal("expected superseded debounce to be ignored")
+	}
+	list, cmd = list.Update(msgs[1])
+	results := filterMsgs(cmd)
+	if len(results) != 1 {
+		t.Fatalf("expected filtering to start, got %v", results)
+	}
+	list, _ = list.Update(results[0])
+	if got := list.VisibleItems(); !slices.Equal(got, []Item{item("foo")}) {
+		t.Fatalf("expected filtered items, got %v", got)
+	}
+}
+
+func TestIncrementalFiltering(t *testing.T) {
+	var searched []string
+	list := New([]Item{item("foo"), item("bar"), item("baz")}, itemDelegate{}, 10, 10)
+	list.IncrementalFiltering = true
+	list.Filter = func(term string, targets []string) []Rank {
+		searched = targets
+		return DefaultFilter(term, targets)
+	}
+	list, _ = list.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+
+	for _, r := range "baz" {
+		var msgs []tea.Msg
+		list, msgs = typeFilter(list, string(r))
+		list, _ = list.Update(msgs[0])
+	}
+
+	if !slices.Equal(searched, []string{"bar", "baz"}) {
+		t.Fatalf("expected only previous matches to be searched, got %v", searched)
+	}
+	if got := list.VisibleItems(); !slices.Equal(got, []Item{item("baz")}) {
+		t.Fatalf("expected refined results, got %v", got)
+	}
+	if got := list.GlobalIndex(); got != 2 {
+		t.Fatalf("expected global index 2, got %d", got)
+	}
+}
+
+func TestParallelFiltering(t *testing.T) {
+	items := make([]Item, 3*filterChunkSize+7)
+	for i := range items {
+		items[i] = item(fmt.Sprintf("item %d", i))
+	}
+
+	filter := func(workers int) []Item {
+		list := New(items, itemDelegate{}, 10, 10)
+		list.FilterWorkers = workers
+		list.SetFilterText("99")
+		return list.VisibleItems()
+	}
+
+	if serial, parallel := filter(1), filter(4); !slices.Equal(serial, parallel) {
+		t.Fatalf("expected parallel filtering to rank like serial filtering")
+	}
+}
+
+type issue struct{ title, status, label string }
+
+func (i issue) FilterValue() string { return i.title }
+func (i issue) FilterFields() map[string]string {
+	return map[string]string{"status": i.status, "label": i.label}
+}
+
+func TestQueryFilter(t *testing.T) {
+	items := []Item{
+		issue{"Crash on start", "open", "bug"},
+		issue{"Add dark mode", "open", "wontfix"},
+		issue{"Crash on exit", "closed", "bug"},
+		issue{"Start faster", "open", "perf"},
+		item("plain item"),
+	}
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"status:open -label:wontfix", []int{0, 3}},
+		{"STATUS:^clo", []int{2}},
+		{`"on start"`, []int{0}},
+		{"/^Cr.sh/ -status:closed", []int{0}},
+		{"-label:bug", []int{1, 3, 4}},
+		{"crash exit", []int{2}},
+		{"/unterminated(", nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, r := range QueryFilter(tt.query, items) {
+			got = append(got, r.Index)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.query, tt.want, got)
+		}
+	}
+
+	ranks := QueryFilter(`"on" ^crash`, items)
+	if len(ranks) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(ranks))
+	}
+	if want := []int{0, 1, 2, 3, 4, 6, 7}; !slices.Equal(ranks[0].MatchedIndexes, want) {
+		t.Fatalf("expected matched indexes %v, got %v", want, ranks[0].MatchedIndexes)
+	}
+	// Matched indexes count runes, as with DefaultFilter.
+	ranks = QueryFilter("café ^ré", []Item{item("résumé café")})
+	if want := []int{0, 1, 7, 8, 9, 10}; len(ranks) != 1 || !slices.Equal(ranks[0].MatchedIndexes, want) {
+		t.Fatalf("expected matched indexes %v, got %v", want, ranks)
+	}
+	defaultRanks := DefaultFilter("café", []string{"résumé café"})
+	if want := []int{7, 8, 9, 10}; len(defaultRanks) != 1 || !slices.Equal(defaultRanks[0].MatchedIndexes, want) {
+		t.Fatalf("expected matched indexes %v, got %v", want, defaultRanks)
+	}
+}
+
+func TestItemFilter(t *testing.T) {
+	list := New([]Item{
+		issue{"Crash on start", "open", "bug"},
+		issue{"Crash on exit", "closed", "bug"},
+	}, itemDelegate{}, 10, 10)
+	list.ItemFilter = QueryFilter
+	list.SetFilterText("crash status:closed")
+
+	if got := list.VisibleItems(); len(got) != 1 || got[0] != list.Items()[1] {
+		t.Fatalf("expected the closed issue, got %v", got)
+	}
+	if got, want := list.MatchesForItem(0), []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Fatalf("expected matched indexes %v, got %v", want, got)
+	}
+
+	// Extending a negated term matches more items, so it isn't refined.
+	list.ResetFilter()
+	list.IncrementalFiltering = true
+	list, _ = list.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "-st" {
+		var msgs []tea.Msg
+		list, msgs = typeFilter(list, string(r))
+		list, _ = list.Update(msgs[0])
+	}
+	if got := list.VisibleItems(); len(got) != 1 || got[0] != list.Items()[1] {
+		t.Fatalf("expected the issue without %q, got %v", "st", got)
+	}
+
+	// A term that turns into a field term as it's typed isn't refined
+	// either: "status" fuzzy matches no titles.
+	list.ResetFilter()
+	list, _ = list.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "status:open" {
+		var msgs []tea.Msg
+		list, msgs = typeFilter(list, string(r))
+		list, _ = list.Update(msgs[0])
+	}
+	if got := list.VisibleItems(); len(got) != 1 || got[0] != list.Items()[0] {
+		t.Fatalf("expected the open issue, got %v", got)
+	}
+}
+
+func TestSortOrders(t *testing.T) {
+	byName := SortOrder{Name: "name", Compare: func(a, b Item) int {
+		return strings.Compare(a.FilterValue(), b.FilterValue())
+	}}
+	byLength := SortOrder{Name: "length", Compare: func(a, b Item) int {
+		return len(a.FilterValue()) - len(b.FilterValue())
+	}}
+
+	list := New([]Item{item("ccc"), item("a"), item("bb"), item("dd")}, itemDelegate{}, 10, 10)
+	list.SetSortOrders(byName, byLength)
+	list.Select(1) // "a"
+
+	s := tea.KeyPressMsg{Code: 's', Text: "s"}
+	list, _ = list.Update(s)
+	if got, want := list.Items(), []Item{item("a"), item("bb"), item("ccc"), item("dd")}; !slices.Equal(got, want) {
+		t.Fatalf("expected items sorted by name %v, got %v", want, got)
+	}
+	if list.SelectedItem() != item("a") {
+		t.Fatalf("expected the cursor to follow the selected item, got %v", list.SelectedItem())
+	}
+	if view := ansi.Strip(list.statusView()); !strings.Contains(view, "sorted by name ↑") {
+		t.Fatalf("expected the sort order in the status bar, got %q", view)
+	}
+
+	// Sorting is stable, in both directions.
+	list, _ = list.Update(s)
+	list, _ = list.Update(tea.KeyPressMsg{Code: 'S', Text: "S"})
+	if got, want := list.Items(), []Item{item("ccc"), item("bb"), item("dd"), item("a")}; !slices.Equal(got, want) {
+		t.Fatalf("expected items sorted by length, descending %v, got %v", want, got)
+	}
+
+	// After the last order, the items return to the order they were given
+	// in, including items inserted while sorted.
+	list.InsertItem(1, item("e"))
+	list.RemoveItem(0)
+	list, _ = list.Update(s)
+	if _, ok := list.SortOrder(); ok {
+		t.Fatal("expected the list not to be sorted")
+	}
+	if got, want := list.Items(), []Item{item("a"), item("e"), item("bb"), item("dd")}; !slices.Equal(got, want) {
+		t.Fatalf("expected the original order %v, got %v", want, got)
+	}
+
+	// Filter results keep their rank unless sorting overrides it.
+	list.SetItems([]Item{item("ab"), item("b"), item("cb")})
+	list.SetSortOrder(0, false)
+	list.SetFilterText("b")
+	if got := list.VisibleItems()[0]; got != item("b") {
+		t.Fatalf("expected best match first, got %v", got)
+	}
+	list.SortFilterResults = true
+	list.SetFilterText("b")
+	if got, want := list.VisibleItems(), []Item{item("ab"), item("b"), item("cb")}; !slices.Equal(got, want) {
+		t.Fatalf("expected filter results sorted by name %v, got %v", want, got)
+	}
+	list.Select(2)
+	list.ToggleSortDirection()
+	if list.SelectedItem() != item("cb") || list.Index() != 0 {
+		t.Fatalf("expected cursor to follow %v to the top, got %v at %d", item("cb"), list.SelectedItem(), list.Index())
+	}
+	if got := list.Items()[list.GlobalIndex()]; got != item("cb") {
+		t.Fatalf("expected global index to point at %v, got %v", item("cb"), got)
+	}
+
+	// Replacing the sort orders returns the items to their original order.
+	list.ResetFilter()
+	list.SetSortOrders(byLength)
+	if _, ok := list.SortOrder(); ok {
+		t.Fatal("expected the list not to be sorted")
+	}
+	if got, want := list.Items(), []Item{item("ab"), item("b"), item("cb")}; !slices.Equal(got, want) {
+		t.Fatalf("expected the original order %v, got %v", want, got)
+	}
+}
+
+type groupedItem struct{ group, title string }
+
+func (i groupedItem) FilterValue() string { return i.title }
+func (i groupedItem) Group() string       { return i.group }
+
+// groupedDelegate renders grouped items by their title.
+type groupedDelegate struct{ itemDelegate }
+
+func (d groupedDelegate) Render(w io.Writer, m Model, index int, listItem Item) {
+	prefix := "  "
+	if index == m.Index() {
+		prefix = "> "
+	}
+	fmt.Fprint(w, prefix+listItem.FilterValue())
+}
+
+func TestGroups(t *testing.T) {
+	list := New([]Item{
+		groupedItem{"Today", "foo"},
+		groupedItem{"Today", "bar"},
+		groupedItem{"Yesterday", "baz"},
+		groupedItem{"Yesterday", "qux"},
+		groupedItem{"Older", "quux"},
+	}, groupedDelegate{}, 20, 20)
+	list.SetShowTitle(false)
+	list.SetShowFilter(false)
+	list.SetShowStatusBar(false)
+	list.SetShowPagination(false)
+	list.SetShowHelp(false)
+
+	view := func() string {
+		lines := strings.Split(ansi.Strip(list.View()), "\n")
+		for i := range lines {
+			lines[i] = strings.TrimSpace(lines[i])
+		}
+		return strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+
+	want := "▾ Today\n> foo\nbar\n▾ Yesterday\nbaz\nqux\n▾ Older\nquux"
+	if got := view(); got != want {
+		t.Fatalf("expected view:\n%s\ngot:\n%s", want, got)
+	}
+
+	// The cursor moves from item to item, skipping headers.
+	list.CursorDown()
+	list.CursorDown()
+	if got := list.SelectedItem(); got != list.Items()[2] {
+		t.Fatalf("expected baz to be selected, got %v", got)
+	}
+
+	// Collapsing a group leaves only its header, which the cursor lands on.
+	list, _ = list.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if !list.GroupCollapsed("Yesterday") {
+		t.Fatal("expected Yesterday to be collapsed")
+	}
+	want = "▾ Today\nfoo\nbar\n▸ Yesterday (2)\n▾ Older\nquux"
+	if got := view(); got != want {
+		t.Fatalf("expected view:\n%s\ngot:\n%s", want, got)
+	}
+	list.CursorDown()
+	if got := list.SelectedItem(); got != list.Items()[4] {
+		t.Fatalf("expected the collapsed items to be skipped, got %v", got)
+	}
+	list.CursorUp()
+	if list.Index() != 2 {
+		t.Fatalf("expected the cursor on the collapsed group, got index %d", list.Index())
+	}
+
+	// The collapsed group's header isn't an item, so it can't be selected.
+	if got := list.SelectedItem(); got != nil {
+		t.Fatalf("expected no selected item on a collapsed group, got %v", got)
+	}
+	list.SetMultiSelect(true)
+	list, _ = list.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if got := list.SelectedItems(); len(got) != 0 {
+		t.Fatalf("expected the collapsed group not to be selected, got %v", got)
+	}
+	list.SetMultiSelect(false)
+
+	help := list.FullHelp()
+	if !slices.ContainsFunc(help, func(column []key.Binding) bool {
+		return len(column) == 1 && slices.Equal(column[0].Keys(), list.KeyMap.ToggleGroup.Keys())
+	}) {
+		t.Fatalf("expected ToggleGroup in its own help column, got %v", help)
+	}
+
+	// Filtering hides empty groups and expands collapsed ones.
+	list.SetFilterText("qu")
+	want = "▾ Yesterday\nqux\n▾ Older\nquux"
+	if got := strings.ReplaceAll(view(), "> ", ""); got != want {
+		t.Fatalf("expected view:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestMouse(t *testing.T) {
+	var items []Item
+	for i := range 10 {
+		items = append(items, item(fmt.Sprint(i)))
+	}
+	list := New(items, groupedDelegate{}, 20, 0)
+	list.SetShowHelp(false)
+	top, _ := list.layout()
+	list.SetHeight(top + 3 + 2) // three items and the pagination
+	list.MouseEnabled = true
+
+	click := func(x, y int) tea.Cmd {
+		var cmd tea.Cmd
+		list, cmd = list.Update(tea.MouseClickMsg{X: x, Y: y, Button: tea.MouseLeft})
+		return cmd
+	}
+
+	if cmd := click(2, top+1); list.Index() != 1 || cmd != nil {
+		t.Fatalf("expected item 1 to be selected, got %d", list.Index())
+	}
+	if click(2, top-1); list.Index() != 1 {
+		t.Fatalf("expected clicking the status bar to do nothing, got %d", list.Index())
+	}
+
+	cmd := click(2, top+2)
+	if cmd != nil {
+		t.Fatal("expected a single click not to choose the item")
+	}
+	cmd = click(2, top+2)
+	msg, ok := cmd().(ItemChosenMsg)
+	if !ok || msg.Index != 2 || msg.Item != item("2") {
+		t.Fatalf("expected a double-click to choose item 2, got %#v", msg)
+	}
+
+	list, _ = list.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if list.Index() != 3 || list.Paginator.Page != 1 {
+		t.Fatalf("expected the wheel to move to item 3 on page 1, got %d on page %d", list.Index(), list.Paginator.Page)
+	}
+
+	// Click the last paginator dot.
+	_, paginationTop := list.layout()
+	dotsY := paginationTop + lipgloss.Height(list.paginationView()) - 1
+	dotsX := list.Styles.PaginationStyle.GetPaddingLeft()
+	click(dotsX+list.Paginator.TotalPages-1, dotsY)
+	if list.Paginator.Page != list.Paginator.TotalPages-1 {
+		t.Fatalf("expected the last page, got %d", list.Paginator.Page)
+	}
+}
+
+// pagedSource is a DataSource serving numbered items, optionally without
+// reporting their total.
+type pagedSource struct {
+	total   int
+	unknown bool
+	fetches *[][2]int
+}
+
+func (s pagedSource) Len() int {
+	if s.unknown {
+		return -1
+	}
+	return s.total
+}
+
+func (s pagedSource) Fetch(start, end int) ([]Item, error) {
+	*s.fetches = append(*s.fetches, [2]int{start, end})
+	var items []Item
+	for i := start; i < min(end, s.total); i++ {
+		items = append(items, item(fmt.Sprint(i)))
+	}
+	return items, nil
+}
+
+// runFetches runs cmd, routing items loaded from a data source back to the
+// list until there's nothing left to load.
+func runFetches(list Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return list
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			list = runFetches(list, cmd)
+		}
+	case fetchedMsg:
+		list, cmd = list.Update(msg)
+		list = runFetches(list, cmd)
+	}
+	return list
+}
+
+func TestDataSource(t *testing.T) {
+	var fetches [][2]int
+	list := New(nil, groupedDelegate{}, 20, 10)
+	list.FetchSize = 4
+
+	cmd := list.SetDataSource(pagedSource{total: 10, fetches: &fetches})
+	if len(list.Items()) != 10 || list.Items()[0] != nil {
+		t.Fatalf("expected 10 placeholders, got %v", list.Items())
+	}
+	if view := ansi.Strip(list.View()); !strings.Contains(view, "Loading…") {
+		t.Fatalf("expected placeholders while loading, got %q", view)
+	}
+	if !list.Loading() {
+		t.Fatal("expected items to be loading")
+	}
+
+	list = runFetches(list, cmd)
+	if list.Loading() || list.Items()[0] != item("0") {
+		t.Fatalf("expected the first items to be loaded, got %v", list.Items())
+	}
+	if list.Items()[9] != nil {
+		t.Fatalf("expected items far from the cursor not to be loaded, got %v", list.Items())
+	}
+
+	list.GoToEnd()
+	list = runFetches(list, list.fetchVisible())
+	if list.Items()[9] != item("9") {
+		t.Fatalf("expected the last items to be loaded, got %v", list.Items())
+	}
+	if !slices.Contains(fetches, [2]int{8, 10}) {
+		t.Fatalf("expected the last chunk to be fetched, got %v", fetches)
+	}
+}
+
+func TestDataSourceUnknownLength(t *testing.T) {
+	var fetches [][2]int
+	list := New(nil, groupedDelegate{}, 20, 10)
+	list.FetchSize = 4
+	list = runFetches(list, list.SetDataSource(pagedSource{total: 6, unknown: true, fetches: &fetches}))
+
+	if view := ansi.Strip(list.statusView()); !strings.Contains(view, "4+ items") {
+		t.Fatalf("expected an open-ended count, got %q", view)
+	}
+
+	// Moving towards the end loads more items until the data runs out.
+	for range 8 {
+		var cmd tea.Cmd
+		list, cmd = list.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+		list = runFetches(list, cmd)
+	}
+	if got := len(list.Items()); got != 6 {
+		t.Fatalf("expected the list to end after 6 items, got %d", got)
+	}
+	if view := ansi.Strip(list.statusView()); !strings.Contains(view, "6 items") {
+		t.Fatalf("expected the final count, got %q", view)
+	}
+}
+
+// failingSource is a pagedSource whose fetches fail while fail is set.
+type failingSource struct {
+	pagedSource
+	fail *bool
+}
+
+func (s failingSource) Fetch(start, end int) ([]Item, error) {
+	if *s.fail {
+		*s.fetches = append(*s.fetches, [2]int{start, end})
+		return nil, errors.New("offline")
+	}
+	return s.pagedSource.Fetch(start, end)
+}
+
+func TestDataSourceError(t *testing.T) {
+	var fetches [][2]int
+	fail := true
+	list := New(nil, groupedDelegate{}, 20, 10)
+	list.FetchSize = 4
+	list.StatusMessageLifetime = time.Millisecond
+	list = runFetches(list, list.SetDataSource(failingSource{
+		pagedSource: pagedSource{total: 10, fetches: &fetches},
+		fail:        &fail,
+	}))
+
+	failed := len(fetches)
+	if failed == 0 || list.Items()[0] != nil {
+		t.Fatalf("expected fetching to fail, got %v", list.Items())
+	}
+	if !strings.Contains(list.statusMessage, "offline") {
+		t.Fatalf("expected the error in a status message, got %q", list.statusMessage)
+	}
+
+	// Failed chunks aren't fetched again when the error goes away.
+	var cmd tea.Cmd
+	list, cmd = list.Update(statusMessageTimeoutMsg{})
+	list = runFetches(list, cmd)
+	if len(fetches) != failed {
+		t.Fatalf("expected no more fetches, got %v", fetches)
+	}
+
+	fail = false
+	list = runFetches(list, list.RetryFetch())
+	if list.Items()[0] != item("0") {
+		t.Fatalf("expected the items to load when retried, got %v", list.Items())
+	}
+}
+
+func TestPreview(t *testing.T) {
+	var items []Item
+	for i := range 5 {
+		items = append(items, item(fmt.Sprint(i)))
+	}
+	preview := NewPreviewModel(New(items, itemDelegate{}, 0, 0), func(it Item, _, _ int) string {
+		lines := make([]string, 10)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("preview %v line %d", it, i)
+		}
+		return strings.Join(lines, "\n")
+	})
+	preview.SetSize(41, 5)
+	if preview.List.Width() != 20 || preview.Viewport.Width() != 20 {
+		t.Fatalf("expected the space to be split evenly, got %d and %d", preview.List.Width(), preview.Viewport.Width())
+	}
+	preview.Init()
+	if !strings.Contains(preview.Viewport.GetContent(), "preview 0") {
+		t.Fatalf("expected item 0 to be previewed, got %q", preview.Viewport.GetContent())
+	}
+
+	preview, _ = preview.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if !strings.Contains(preview.Viewport.GetContent(), "preview 1") {
+		t.Fatalf("expected item 1 to be previewed, got %q", preview.Viewport.GetContent())
+	}
+
+	preview, _ = preview.Update(tea.KeyPressMsg{Code: 'w', Mod: tea.ModCtrl})
+	if !preview.PreviewFocused() {
+		t.Fatal("expected the preview to be focused")
+	}
+	preview, _ = preview.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if preview.List.Index() != 1 || preview.Viewport.YOffset() != 1 {
+		t.Fatalf("expected the preview to scroll, got item %d at offset %d", preview.List.Index(), preview.Viewport.YOffset())
+	}
+
+	// The list still force quits while the preview is focused.
+	_, cmd := preview.Update(tea.KeyPressMsg{Code: 'c', Mod: tea.ModCtrl})
+	if cmd == nil || cmd() != (tea.QuitMsg{}) {
+		t.Fatal("expected ctrl+c to quit")
+	}
+	preview.SetPreviewFocused(false)
+
+	// Async previews show a placeholder and drop stale results.
+	preview.Async = true
+	preview, stale := preview.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if preview.Viewport.GetContent() != preview.Placeholder {
+		t.Fatalf("expected the placeholder, got %q", preview.Viewport.GetContent())
+	}
+	preview, cmd = preview.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	preview, _ = preview.Update(stale())
+	if preview.Viewport.GetContent() != preview.Placeholder {
+		t.Fatalf("expected a stale preview to be dropped, got %q", preview.Viewport.GetContent())
+	}
+	preview, _ = preview.Update(cmd())
+	if !strings.Contains(preview.Viewport.GetContent(), "preview 3") {
+		t.Fatalf("expected item 3 to be previewed, got %q", preview.Viewport.GetContent())
+	}
+}
+
+// taggedItem is comparable, but may hold a value that isn't.
+type taggedItem struct {
+	name string
+	tag  any
+}
+
+func (i taggedItem) FilterValue() string { return i.name }
+
+func TestSameItem(t *testing.T) {
+	if !sameItem(item("a"), item("a")) || sameItem(item("a"), item("b")) {
+		t.Fatal("expected comparable items to be compared")
+	}
+	a := taggedItem{"a", []string{"x"}}
+	if !sameItem(a, a) || !sameItem(a, taggedItem{"a", 1}) {
+		t.Fatal("expected items holding values that can't be compared to be the same")
+	}
+}
+
+func TestReordering(t *testing.T) {
+	items := []Item{item("a"), item("b"), item("c"), item("d")}
+	list := New(items, itemDelegate{}, 10, 20)
+	list.SetReorderingEnabled(true)
+	list.SetMultiSelect(true)
+	list.ToggleSelected(0)
+
+	press := func(r rune, text string) tea.Msg {
+		var cmd tea.Cmd
+		list, cmd = list.Update(tea.KeyPressMsg{Code: r, Text: text})
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	msg := press('J', "J")
+	if moved, ok := msg.(ItemMovedMsg); !ok || moved.From != 0 || moved.To != 1 || moved.Item != item("a") {
+		t.Fatalf("expected item a to move from 0 to 1, got %#v", msg)
+	}
+	if list.Index() != 1 || list.SelectedItem() != item("a") {
+		t.Fatalf("expected the cursor to follow the item, got %d", list.Index())
+	}
+	if !list.IsSelected(1) || list.IsSelected(0) {
+		t.Fatal("expected the selection to follow the item")
+	}
+	if items[0] != item("a") {
+		t.Fatal("expected the original items not to be modified")
+	}
+
+	list, _ = list.Update(tea.KeyPressMsg{Code: tea.KeyEnd, Mod: tea.ModAlt})
+	var got []string
+	for _, it := range list.Items() {
+		got = append(got, string(it.(item)))
+	}
+	if strings.Join(got, "") != "bcda" {
+		t.Fatalf("expected bcda, got %v", got)
+	}
+
+	// Moving is disabled while a filter is applied.
+	list.SetFilterText("b")
+	list.SetFilterState(FilterApplied)
+	if msg := press('K', "K"); msg != nil {
+		t.Fatalf("expected no move while filtered, got %#v", msg)
+	}
+
+	// Moves made while filtered keep the filtered items pointing at the
+	// right items.
+	list.MoveItem(0, 3)
+	if list.SelectedItem() != item("b") || list.GlobalIndex() != 3 {
+		t.Fatalf("expected b to be selected at 3, got %v at %d", list.SelectedItem(), list.GlobalIndex())
+	}
+	list.ResetFilter()
+
+	// Drag item c over item a.
+	list.MouseEnabled = true
+	top, _ := list.layout()
+	list, _ = list.Update(tea.MouseClickMsg{X: 1, Y: top, Button: tea.MouseLeft})
+	list, _ = list.Update(tea.MouseMotionMsg{X: 1, Y: top + 2, Button: tea.MouseLeft})
+	list, cmd := list.Update(tea.MouseReleaseMsg{X: 1, Y: top + 2, Button: tea.MouseLeft})
+	if moved, ok := cmd().(ItemMovedMsg); !ok || moved.From != 0 || moved.To != 2 || moved.Item != item("c") {
+		t.Fatalf("expected item c to be dragged from 0 to 2, got %#v", moved)
+	}
+	got = got[:0]
+	for _, it := range list.Items() {
+		got = append(got, string(it.(item)))
+	}
+	if strings.Join(got, "") != "dacb" {
+		t.Fatalf("expected dacb, got %v", got)
+	}
+}
+
+type gridDelegate struct{ itemDelegate }
+
+func (d gridDelegate) CellWidth() int { return 4 }
+
+func (d gridDelegate) Render(w io.Writer, m Model, index int, listItem Item) {
+	prefix := " "
+	if index == m.Index() {
+		prefix = ">"
+	}
+	fmt.Fprint(w, prefix+listItem.FilterValue())
+}
+
+func TestGrid(t *testing.T) {
+	var items []Item
+	for i := range 10 {
+		items = append(items, item(fmt.Sprint(i)))
+	}
+	list := New(items, gridDelegate{}, 13, 0)
+	list.SetShowTitle(false)
+	list.SetShowStatusBar(false)
+	list.SetShowHelp(false)
+	list.SetShowPagination(false)
+	list.SetShowFilter(false)
+	list.SetHeight(2)
+
+	// Three columns of two rows per page.
+	if list.Paginator.PerPage != 6 || list.Paginator.TotalPages != 2 {
+		t.Fatalf("expected 2 pages of 6 items, got %d pages of %d", list.Paginator.TotalPages, list.Paginator.PerPage)
+	}
+	lines := strings.Split(ansi.Strip(list.View()), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	want := ">0   1   2\n 3   4   5"
+	if view := strings.Join(lines, "\n"); !strings.HasPrefix(view, want) {
+		t.Fatalf("expected the grid\n%s\ngot\n%s", want, view)
+	}
+
+	press := func(code rune) {
+		list, _ = list.Update(tea.KeyPressMsg{Code: code})
+	}
+	for _, tc := range []struct {
+		key  rune
+		want int
+	}{
+		{tea.KeyRight, 1},
+		{tea.KeyDown, 4},
+		{tea.KeyDown, 7}, // Same column on the next page.
+		{tea.KeyDown, 9}, // The last row is shorter.
+		{tea.KeyLeft, 8},
+		{tea.KeyUp, 5}, // Same column on the previous page.
+		{tea.KeyUp, 2},
+		{tea.KeyLeft, 1},
+	} {
+		press(tc.key)
+		if list.Index() != tc.want {
+			t.Fatalf("expected item %d to be selected, got %d", tc.want, list.Index())
+		}
+	}
+
+	// Clicking a cell selects its item.
+	list.MouseEnabled = true
+	list, _ = list.Update(tea.MouseClickMsg{X: 5, Y: 1, Button: tea.MouseLeft})
+	if list.Index() != 4 {
+		t.Fatalf("expected the click to select item 4, got %d", list.Index())
+	}
+
+	// Filtering reflows the grid.
+	list.SetFilterText("1")
+	list.SetFilterState(FilterApplied)
+	if view := ansi.Strip(list.View()); !strings.HasPrefix(view, ">1") || list.Paginator.TotalPages != 1 {
+		t.Fatalf("expected a single filtered item, got\n%s", view)
+	}
+}
+
+func TestRestoreState(t *testing.T) {
+	var items []Item
+	for i := range 20 {
+		items = append(items, identifiedItem{id: fmt.Sprint(i), title: fmt.Sprintf("item %d", i)})
+	}
+	list := New(items, itemDelegate{}, 10, 0)
+	list.SetShowHelp(false)
+	top, _ := list.layout()
+	list.SetHeight(top + 5 + 2) // five items and the pagination
+	list.SetFilterText("1")
+	list.Select(7) // item 16
+
+	b, err := json.Marshal(list.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state State
+	if err := json.Unmarshal(b, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := New(nil, itemDelegate{}, 10, list.Height())
+	restored.SetShowHelp(false)
+	restored.SetItems(items)
+	restored.Restore(state)
+	if restored.FilterState() != FilterApplied || restored.FilterValue() != "1" {
+		t.Fatalf("expected the filter to be restored, got %q (%s)", restored.FilterValue(), restored.FilterState())
+	}
+	if restored.SelectedItem() != items[16] || restored.Paginator.Page != 1 {
+		t.Fatalf("expected item 16 on page 1 to be selected, got %v on page %d", restored.SelectedItem(), restored.Paginator.Page)
+	}
+
+	// Without the selected item, the cursor stays in place, clamped to the
+	// items left.
+	restored = New(nil, itemDelegate{}, 10, list.Height())
+	restored.SetShowHelp(false)
+	restored.SetItems(items[:12])
+	restored.Restore(state)
+	if restored.Paginator.Page != 0 || restored.Index() != 2 {
+		t.Fatalf("expected the cursor to be clamped to item 2 on page 0, got %d on page %d", restored.Index(), restored.Paginator.Page)
+	}
+}
+
+func TestDarkMode(t *testing.T) {
+	list := New([]Item{item("foo")}, NewDefaultDelegate(), 10, 10)
+
+	list, _ = list.Update(tea.BackgroundColorMsg{Color: color.White})
+	if list.DarkMode() {
+		t.Fatal("expected light mode on a light background")
+	}
+	if !reflect.DeepEqual(list.Help.Styles, help.DefaultStyles(false)) {
+		t.Fatal("expected the help to switch to light styles")
+	}
+	if !reflect.DeepEqual(list.FilterInput.Styles(), textinput.DefaultStyles(false)) {
+		t.Fatal("expected the filter input to switch to light styles")
+	}
+	if d := list.delegate.(DefaultDelegate); !reflect.DeepEqual(d.Styles, NewDefaultItemStyles(false)) {
+		t.Fatal("expected the delegate to switch to light styles")
+	}
+	if !reflect.DeepEqual(list.Styles.Title, DefaultStyles(false).Title) {
+		t.Fatal("expected the list to switch to light styles")
+	}
+
+	list, _ = list.Update(tea.BackgroundColorMsg{Color: color.Black})
+	if !list.DarkMode() || !reflect.DeepEqual(list.Help.Styles, help.DefaultStyles(true)) {
+		t.Fatal("expected dark mode on a dark background")
+	}
+
+	// Styles set by the application are kept, even when they're the
+	// defaults.
+	list.SetStyles(DefaultStyles(true))
+	d := NewDefaultDelegate()
+	d.SetStyles(NewDefaultItemStyles(true))
+	list.SetDelegate(d)
+	list, _ = list.Update(tea.BackgroundColorMsg{Color: color.White})
+	if !reflect.DeepEqual(list.Styles.Title, DefaultStyles(true).Title) {
+		t.Fatal("expected custom styles to be left alone")
+	}
+	if d := list.delegate.(DefaultDelegate); !reflect.DeepEqual(d.Styles, NewDefaultItemStyles(true)) {
+		t.Fatal("expected custom delegate styles to be left alone")
+	}
+}
+
+type actionItem string
+
+func (i actionItem) FilterValue() string { return string(i) }
+func (i actionItem) Actions() []Action {
+	return []Action{
+		{Name: "open"},
+		{Name: "delete", Key: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "delete"))},
+	}
+}
+
+func TestActions(t *testing.T) {
+	list := New([]Item{actionItem("foo"), actionItem("bar")}, itemDelegate{}, 20, 0)
+	list.SetShowHelp(false)
+	top, _ := list.layout()
+	list.SetHeight(top + 6)
+
+	update := func(msg tea.Msg) tea.Msg {
+		var cmd tea.Cmd
+		list, cmd = list.Update(msg)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	update(tea.KeyPressMsg{Code: tea.KeyDown})
+	update(tea.KeyPressMsg{Code: '.', Text: "."})
+	if !list.ActionsOpen() {
+		t.Fatal("expected the action menu to be open")
+	}
+	view := ansi.Strip(list.View())
+	if !strings.Contains(view, "open") || !strings.Contains(view, "delete x") {
+		t.Fatalf("expected the menu to list the actions, got\n%s", view)
+	}
+
+	update(tea.KeyPressMsg{Code: tea.KeyDown})
+	msg, ok := update(tea.KeyPressMsg{Code: tea.KeyEnter}).(ActionMsg)
+	if !ok || msg.Action.Name != "delete" || msg.Index != 1 || msg.Item != actionItem("bar") {
+		t.Fatalf("expected the delete action on bar, got %#v", msg)
+	}
+	if list.ActionsOpen() {
+		t.Fatal("expected the menu to close")
+	}
+
+	// Action keys work without opening the menu.
+	msg, ok = update(tea.KeyPressMsg{Code: 'x', Text: "x"}).(ActionMsg)
+	if !ok || msg.Action.Name != "delete" {
+		t.Fatalf("expected the delete action, got %#v", msg)
+	}
+
+	update(tea.KeyPressMsg{Code: '.', Text: "."})
+	update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if list.ActionsOpen() {
+		t.Fatal("expected escape to close the menu")
+	}
+	if countEnabledBindings([][]key.Binding{list.actionsHelp()}) != 1 {
+		t.Fatal("expected the action keys in the help")
+	}
+}
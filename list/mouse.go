@@ -0,0 +1,193 @@
+package list
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"charm.land/bubbles/v2/paginator"
+)
+
+// ItemChosenMsg is sent when an item is double-clicked with the mouse.
+type ItemChosenMsg struct {
+	// Index is the index of the item in the filtered list of items.
+	Index int
+	Item  Item
+}
+
+// ItemAt returns the index, in the filtered list of items, of the item
+// rendered at the given coordinates. Coordinates are relative to the list
+// itself: (0, 0) is its top-left cell. Callers that render the list at an
+// offset must subtract that offset first.
+//
+// Clicking an item's group header counts as clicking the item.
+func (m Model) ItemAt(x, y int) (int, bool) {
+	index, _, ok := m.itemAt(x, y)
+	return index, ok
+}
+
+// itemAt returns the index of the item rendered at the given coordinates,
+// and whether the coordinates are on its group header.
+func (m Model) itemAt(x, y int) (index int, header bool, ok bool) {
+	if x < 0 || x >= m.width {
+		return 0, false, false
+	}
+
+	top, _ := m.layout()
+	y -= top
+	items := m.VisibleItems()
+	if y < 0 || len(items) == 0 {
+		return 0, false, false
+	}
+
+	if m.isGrid() {
+		index, ok := m.gridCellAt(x, y)
+		return index, false, ok
+	}
+
+	start, end := m.pageBounds(len(items))
+	if m.scrolling() {
+		start, end = m.visibleRange(items)
+	}
+
+	row := 0
+	for i := start; i < end; i++ {
+		h := m.rowHeight(items, i)
+		if h == 0 {
+			continue
+		}
+		if row > 0 {
+			row += m.delegate.Spacing()
+		}
+		if y >= row && y < row+h {
+			header := m.isGroupHeader(items, i) && y < row+m.groupHeaderHeight(items, i)
+			return i, header, true
+		}
+		row += h
+	}
+	return 0, false, false
+}
+
+// pageAt returns the page of the paginator dot rendered at the given
+// coordinates.
+func (m Model) pageAt(x, y int) (int, bool) {
+	if !m.showPagination || m.scrolling() || m.Paginator.Type != paginator.Dots {
+		return 0, false
+	}
+	dots := m.Paginator.View()
+	if m.Paginator.TotalPages < 2 || ansi.StringWidth(dots) > m.width { //nolint:mnd
+		// Too many pages for dots.
+		return 0, false
+	}
+
+	_, paginationTop := m.layout()
+	if y != paginationTop+lipgloss.Height(m.paginationView())-1 {
+		return 0, false
+	}
+
+	style := m.Styles.PaginationStyle
+	x -= style.GetMarginLeft() + style.GetPaddingLeft()
+	for page := range m.Paginator.TotalPages {
+		dot := m.Paginator.InactiveDot
+		if page == m.Paginator.Page {
+			dot = m.Paginator.ActiveDot
+		}
+		w := ansi.StringWidth(dot)
+		if x >= 0 && x < w {
+			return page, true
+		}
+		x -= w
+	}
+	return 0, false
+}
+
+// layout returns the rows where items and the pagination start.
+func (m Model) layout() (itemsTop, paginationTop int) {
+	availHeight := m.height
+	if m.showTitle || (m.showFilter && m.filteringEnabled) {
+		h := lipgloss.Height(m.titleView())
+		itemsTop += h
+		availHeight -= h
+	}
+	if m.showStatusBar {
+		h := lipgloss.Height(m.statusView())
+		itemsTop += h
+		availHeight -= h
+	}
+	if m.showPagination {
+		availHeight -= lipgloss.Height(m.paginationView())
+	}
+	if m.showHelp {
+		availHeight -= lipgloss.Height(m.helpView())
+	}
+	return itemsTop, itemsTop + max(availHeight, lipgloss.Height(m.populatedView()))
+}
+
+// handleMouse handles mouse messages while browsing.
+func (m *Model) handleMouse(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.MouseWheelMsg:
+		for range max(1, m.MouseWheelDelta) {
+			switch msg.Button { //nolint:exhaustive
+			case tea.MouseWheelUp:
+				m.CursorUp()
+			case tea.MouseWheelDown:
+				m.CursorDown()
+			}
+		}
+
+	case tea.MouseMotionMsg:
+		if msg.Button == tea.MouseLeft {
+			m.drag(msg.X, msg.Y)
+		}
+
+	case tea.MouseReleaseMsg:
+		return m.drop()
+
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft {
+			return nil
+		}
+
+		if page, ok := m.pageAt(msg.X, msg.Y); ok {
+			m.Paginator.Page = page
+			m.cursor = clamp(m.cursor, 0, m.maxCursorIndex())
+			m.skipCollapsed()
+			return nil
+		}
+
+		index, header, ok := m.itemAt(msg.X, msg.Y)
+		if !ok {
+			return nil
+		}
+		m.Select(index)
+
+		if header && !m.isCollapsed(m.VisibleItems(), index) {
+			// Clicking an expanded group's header collapses it.
+			m.ToggleGroup()
+			m.lastClick = time.Time{}
+			return nil
+		}
+		m.startDrag()
+
+		now := time.Now()
+		double := index == m.lastClickIndex && now.Sub(m.lastClick) <= m.DoubleClickInterval
+		m.lastClick, m.lastClickIndex = now, index
+		if !double {
+			return nil
+		}
+		m.lastClick = time.Time{}
+
+		if header {
+			m.ToggleGroup()
+			return nil
+		}
+		item := m.SelectedItem()
+		return func() tea.Msg {
+			return ItemChosenMsg{Index: index, Item: item}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package list
+
+// IdentifiableItem is an item with a stable identity. When multi-select is
+// enabled, selections of identifiable items are tracked by ID rather than by
+// position, so they survive the items being replaced with SetItems.
+type IdentifiableItem interface {
+	Item
+
+	// ID returns a value that uniquely identifies the item.
+	ID() string
+}
+
+// selectionKey returns the key used to track the selection of the item at the
+// given index in the unfiltered list of items.
+func (m Model) selectionKey(globalIndex int) any {
+	if i, ok := m.items[globalIndex].(IdentifiableItem); ok {
+		return i.ID()
+	}
+	return globalIndex
+}
+
+// SetMultiSelect enables or disables multi-select mode. When enabled, the
+// user can select several items at once. Disabling multi-select clears the
+// selection.
+func (m *Model) SetMultiSelect(v bool) {
+	m.multiSelect = v
+	if !v {
+		m.ClearSelection()
+	}
+	m.updateKeybindings()
+}
+
+// MultiSelect returns whether multi-select mode is enabled.
+func (m Model) MultiSelect() bool {
+	return m.multiSelect
+}
+
+// IsSelected returns whether the item at the given index, as it is stored in
+// the filtered list of items, is selected in multi-select mode.
+//
+// See DefaultDelegate.Render for a usage example.
+func (m Model) IsSelected(index int) bool {
+	gi, ok := m.globalIndexOf(index)
+	if !ok {
+		return false
+	}
+	_, ok = m.selected[m.selectionKey(gi)]
+	return ok
+}
+
+// SetSelected selects or deselects the item at the given index, as it is
+// stored in the filtered list of items.
+func (m *Model) SetSelected(index int, v bool) {
+	gi, ok := m.globalIndexOf(index)
+	if !ok {
+		return
+	}
+	k := m.selectionKey(gi)
+	if !v {
+		delete(m.selected, k)
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[any]struct{})
+	}
+	m.selected[k] = struct{}{}
+}
+
+// ToggleSelected toggles the selection of the item at the given index, as it
+// is stored in the filtered list of items.
+func (m *Model) ToggleSelected(index int) {
+	m.SetSelected(index, !m.IsSelected(index))
+}
+
+// SelectAll selects all visible items.
+func (m *Model) SelectAll() {
+	for i := range m.VisibleItems() {
+		m.SetSelected(i, true)
+	}
+}
+
+// SelectNone deselects all visible items. Items hidden by the current filter
+// keep their selection. Use ClearSelection to deselect everything.
+func (m *Model) SelectNone() {
+	for i := range m.VisibleItems() {
+		m.SetSelected(i, false)
+	}
+}
+
+// InvertSelection inverts the selection of all visible items.
+func (m *Model) InvertSelection() {
+	for i := range m.VisibleItems() {
+		m.ToggleSelected(i)
+	}
+}
+
+// ClearSelection deselects all items, including items hidden by the current
+// filter.
+func (m *Model) ClearSelection() {
+	m.selected = nil
+}
+
+// SelectedItems returns the items selected in multi-select mode, in the
+// order they appear in the unfiltered list of items.
+func (m Model) SelectedItems() []Item {
+	if len(m.selected) == 0 {
+		return nil
+	}
+	var items []Item
+	for i, item := range m.items {
+		if _, ok := m.selected[m.selectionKey(i)]; ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// globalIndexOf converts an index in the filtered list of items into an index
+// in the unfiltered list of items.
+func (m Model) globalIndexOf(index int) (int, bool) {
+	if m.filterState != Unfiltered {
+		if index < 0 || index >= len(m.filteredItems) {
+			return 0, false
+		}
+		return m.filteredItems[index].index, true
+	}
+	if index < 0 || index >= len(m.items) {
+		return 0, false
+	}
+	return index, true
+}
+
+// shiftSelection adjusts positional selections after an item has been
+// inserted (delta 1) or removed (delta -1) at the given index.
+func (m *Model) shiftSelection(index, delta int) {
+	if len(m.selected) == 0 {
+		return
+	}
+	shifted := make(map[any]struct{}, len(m.selected))
+	for k := range m.selected {
+		i, ok := k.(int)
+		switch {
+		case !ok || i < index:
+			shifted[k] = struct{}{}
+		case delta < 0 && i == index:
+			// The selected item was removed.
+		default:
+			shifted[i+delta] = struct{}{}
+		}
+	}
+	m.selected = shifted
+}
+
+// dropPositionalSelection forgets selections that are tracked by position,
+// as positions are meaningless once the items have been replaced.
+func (m *Model) dropPositionalSelection() {
+	for k := range m.selected {
+		if _, ok := k.(int); ok {
+			delete(m.selected, k)
+		}
+	}
+}
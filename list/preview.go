@@ -0,0 +1,279 @@
+package list
+
+import (
+	"reflect"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+)
+
+// PreviewFunc renders the preview of an item at the given size. It's called
+// with a nil item when no item is selected.
+type PreviewFunc func(item Item, width, height int) string
+
+// PreviewPosition is where the preview is shown relative to the list.
+type PreviewPosition int
+
+// Preview positions.
+const (
+	PreviewRight PreviewPosition = iota
+	PreviewBottom
+)
+
+// PreviewKeyMap defines keybindings for PreviewModel.
+type PreviewKeyMap struct {
+	// SwitchFocus moves the focus between the list and the preview. While
+	// the preview is focused, keys scroll the preview.
+	SwitchFocus key.Binding
+}
+
+// DefaultPreviewKeyMap returns a default set of keybindings for
+// PreviewModel.
+func DefaultPreviewKeyMap() PreviewKeyMap {
+	return PreviewKeyMap{
+		SwitchFocus: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "switch pane"),
+		),
+	}
+}
+
+// previewMsg carries a preview rendered asynchronously.
+type previewMsg struct {
+	id      int
+	tag     int
+	content string
+}
+
+// PreviewModel lays out a list next to a scrollable preview of the selected
+// item, similar to fzf's --preview option.
+type PreviewModel struct {
+	// List is the list being previewed.
+	List Model
+
+	// Viewport displays the preview.
+	Viewport viewport.Model
+
+	// Preview renders the preview of the selected item.
+	Preview PreviewFunc
+
+	// Position is where the preview is shown. By default it's on the right.
+	Position PreviewPosition
+
+	// Ratio is the portion of the space taken up by the preview, between 0
+	// and 1. By default this is 0.5.
+	Ratio float64
+
+	// Async renders previews in a tea.Cmd, so slow previews don't block the
+	// list. Placeholder is shown while a preview is being rendered.
+	Async       bool
+	Placeholder string
+
+	KeyMap PreviewKeyMap
+
+	// DividerStyle styles the line between the list and the preview.
+	DividerStyle lipgloss.Style
+
+	id            int
+	tag           int
+	width         int
+	height        int
+	previewFocus  bool
+	previewed     Item
+	previewIndex  int
+	hasPreviewed  bool
+	previewWidth  int
+	previewHeight int
+}
+
+// NewPreviewModel returns a new PreviewModel previewing the items of the given
+// list with the given function.
+func NewPreviewModel(l Model, preview PreviewFunc) PreviewModel {
+	return PreviewModel{
+		List:        l,
+		Viewport:    viewport.New(),
+		Preview:     preview,
+		Ratio:       0.5, //nolint:mnd
+		Placeholder: "Loading" + ellipsis,
+		KeyMap:      DefaultPreviewKeyMap(),
+		DividerStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")),
+		id: nextID(),
+	}
+}
+
+// SetSize sets the size of the list and preview combined.
+func (m *PreviewModel) SetSize(width, height int) {
+	m.width, m.height = width, height
+
+	ratio := clamp(m.Ratio, 0, 1)
+	switch m.Position {
+	case PreviewBottom:
+		previewHeight := int(float64(max(0, height-1)) * ratio)
+		m.List.SetSize(width, max(0, height-1-previewHeight))
+		m.Viewport.SetWidth(width)
+		m.Viewport.SetHeight(previewHeight)
+	default:
+		previewWidth := int(float64(max(0, width-1)) * ratio)
+		m.List.SetSize(max(0, width-1-previewWidth), height)
+		m.Viewport.SetWidth(previewWidth)
+		m.Viewport.SetHeight(height)
+	}
+}
+
+// Width returns the width of the list and preview combined.
+func (m PreviewModel) Width() int {
+	return m.width
+}
+
+// Height returns the height of the list and preview combined.
+func (m PreviewModel) Height() int {
+	return m.height
+}
+
+// PreviewFocused returns whether the preview, rather than the list, has
+// focus.
+func (m PreviewModel) PreviewFocused() bool {
+	return m.previewFocus
+}
+
+// SetPreviewFocused focuses the preview, or the list.
+func (m *PreviewModel) SetPreviewFocused(v bool) {
+	m.previewFocus = v
+}
+
+// Refresh renders the preview of the selected item again, such as after the
+// item changed. Changes to items that can't be compared with ==, such as
+// slices, aren't noticed otherwise. This returns a command.
+func (m *PreviewModel) Refresh() tea.Cmd {
+	m.hasPreviewed = false
+	return m.updatePreview()
+}
+
+// Init returns a command that renders the initial preview.
+func (m *PreviewModel) Init() tea.Cmd {
+	return m.Refresh()
+}
+
+// Update is the Bubble Tea update loop.
+func (m PreviewModel) Update(msg tea.Msg) (PreviewModel, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case previewMsg:
+		if msg.id == m.id && msg.tag == m.tag {
+			m.Viewport.SetContent(msg.content)
+			m.Viewport.GotoTop()
+		}
+		return m, nil
+
+	case tea.KeyPressMsg:
+		if key.Matches(msg, m.KeyMap.SwitchFocus) && m.List.FilterState() != Filtering {
+			m.previewFocus = !m.previewFocus
+			return m, nil
+		}
+		// The list handles ForceQuit wherever the focus is.
+		if m.previewFocus && !key.Matches(msg, m.List.KeyMap.ForceQuit) {
+			var cmd tea.Cmd
+			m.Viewport, cmd = m.Viewport.Update(msg)
+			return m, cmd
+		}
+
+	case tea.MouseWheelMsg:
+		if m.inPreview(msg.X, msg.Y) {
+			var cmd tea.Cmd
+			m.Viewport, cmd = m.Viewport.Update(msg)
+			return m, cmd
+		}
+
+	case tea.MouseClickMsg:
+		m.previewFocus = m.inPreview(msg.X, msg.Y)
+		if m.previewFocus {
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	cmds = append(cmds, cmd, m.updatePreview())
+
+	return m, tea.Batch(cmds...)
+}
+
+// inPreview returns whether the given coordinates are on the preview.
+func (m PreviewModel) inPreview(x, y int) bool {
+	if m.Position == PreviewBottom {
+		return y > m.List.Height()
+	}
+	return x > m.List.Width()
+}
+
+// updatePreview renders the preview if the selected item, or the size of the
+// preview, changed.
+func (m *PreviewModel) updatePreview() tea.Cmd {
+	if m.Preview == nil {
+		return nil
+	}
+
+	item := m.List.SelectedItem()
+	index := m.List.GlobalIndex()
+	if item == nil {
+		index = -1
+	}
+	width, height := m.Viewport.Width(), m.Viewport.Height()
+	if m.hasPreviewed && index == m.previewIndex && sameItem(item, m.previewed) &&
+		width == m.previewWidth && height == m.previewHeight {
+		return nil
+	}
+	m.hasPreviewed = true
+	m.previewed, m.previewIndex = item, index
+	m.previewWidth, m.previewHeight = width, height
+
+	m.tag++
+	if !m.Async {
+		m.Viewport.SetContent(m.Preview(item, width, height))
+		m.Viewport.GotoTop()
+		return nil
+	}
+
+	m.Viewport.SetContent(m.Placeholder)
+	preview, id, tag := m.Preview, m.id, m.tag
+	return func() tea.Msg {
+		return previewMsg{id: id, tag: tag, content: preview(item, width, height)}
+	}
+}
+
+// sameItem returns whether a and b are the same item. Items of types that
+// can't be compared are only told apart by their index, so Refresh must be
+// called when such an item changes in place.
+func sameItem(a, b Item) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+	// Comparable types may still hold values that can't be compared, such
+	// as slices in interface fields.
+	if !va.Comparable() || !vb.Comparable() {
+		return true
+	}
+	return va.Equal(vb)
+}
+
+// View renders the list and the preview.
+func (m PreviewModel) View() string {
+	if m.Position == PreviewBottom {
+		divider := m.DividerStyle.Render(strings.Repeat("─", m.width))
+		return lipgloss.JoinVertical(lipgloss.Left, m.List.View(), divider, m.Viewport.View())
+	}
+
+	list := lipgloss.NewStyle().Width(m.List.Width()).Height(m.height).Render(m.List.View())
+	divider := m.DividerStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", m.height), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, divider, m.Viewport.View())
+}
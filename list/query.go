@@ -0,0 +1,296 @@
+package list
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// FieldItem is an item with named fields that can be filtered individually
+// with QueryFilter, such as "status" in the query "status:open".
+type FieldItem interface {
+	Item
+
+	// FilterFields returns the values of the item's filterable fields, keyed
+	// by field name. Field names are matched case-insensitively.
+	FilterFields() map[string]string
+}
+
+// QueryFilter is an ItemFilterFunc that filters items with a structured
+// query. A query is made of whitespace-separated terms, all of which must
+// match:
+//
+//	foo          fuzzy matches the item's filter value
+//	"foo bar"    matches the exact phrase
+//	^foo, foo$   matches the start or end of the value
+//	/fo+/        matches a regular expression
+//	status:open  matches the named field of a FieldItem
+//	-foo         excludes items that match the term
+//
+// Field terms and phrases match case-insensitively. Items are ranked by their
+// fuzzy matches as with DefaultFilter, and otherwise keep their order.
+//
+// To use it, set Model.ItemFilter:
+//
+//	l.ItemFilter = list.QueryFilter
+func QueryFilter(query string, items []Item) []Rank {
+	terms := parseQuery(query)
+
+	var fuzzyTerms []string
+	ranks := make([]Rank, 0, len(items))
+	for _, t := range terms {
+		if t.fuzzy {
+			fuzzyTerms = append(fuzzyTerms, t.value)
+		}
+	}
+
+outer:
+	for i, item := range items {
+		r := Rank{Index: i}
+		for _, t := range terms {
+			if t.fuzzy {
+				continue
+			}
+			value, ok := t.target(item)
+			loc := []int(nil)
+			if ok {
+				loc = t.re.FindStringIndex(value)
+			}
+			if (loc != nil) == t.negate {
+				continue outer
+			}
+			if loc != nil && t.field == "" {
+				r.MatchedIndexes = append(r.MatchedIndexes, runeRange(value, loc[0], loc[1])...)
+			}
+		}
+		ranks = append(ranks, r)
+	}
+
+	for _, term := range fuzzyTerms {
+		targets := make([]string, len(ranks))
+		for i, r := range ranks {
+			targets[i] = items[r.Index].FilterValue()
+		}
+
+		// Matches are reported in order, so the ranks can be narrowed down
+		// in place.
+		matched := ranks[:0]
+		for _, m := range fuzzy.FindNoSort(term, targets) {
+			r := ranks[m.Index]
+			r.Score += m.Score
+			r.MatchedIndexes = append(r.MatchedIndexes, runeIndexes(targets[m.Index], m.MatchedIndexes)...)
+			matched = append(matched, r)
+		}
+		ranks = matched
+	}
+
+	for i := range ranks {
+		slices.Sort(ranks[i].MatchedIndexes)
+		ranks[i].MatchedIndexes = slices.Compact(ranks[i].MatchedIndexes)
+	}
+	if len(fuzzyTerms) > 0 {
+		sort.SliceStable(ranks, func(i, j int) bool {
+			return ranks[i].Score > ranks[j].Score
+		})
+	}
+	return ranks
+}
+
+// queryTerm is a single term of a query parsed by parseQuery.
+type queryTerm struct {
+	field  string         // field to match, empty for the filter value
+	value  string         // the term as typed, without field and negation
+	negate bool           // whether matching items are excluded
+	fuzzy  bool           // whether the value is matched fuzzily
+	re     *regexp.Regexp // the matcher for terms that aren't fuzzy
+}
+
+// target returns the value the term matches against, and whether the item
+// has it.
+func (t queryTerm) target(item Item) (string, bool) {
+	if t.field == "" {
+		return item.FilterValue(), true
+	}
+	fi, ok := item.(FieldItem)
+	if !ok {
+		return "", false
+	}
+	for name, value := range fi.FilterFields() {
+		if strings.EqualFold(name, t.field) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// parseQuery splits a query into terms. Empty terms are dropped.
+func parseQuery(query string) []queryTerm {
+	var terms []queryTerm
+	for _, tok := range splitQuery(query) {
+		var t queryTerm
+
+		// Dashes and colons only have special meaning outside of quotes.
+		raw, quoteStart := tok.text, tok.quoteStart
+		if quoteStart > 0 && raw[0] == '-' {
+			t.negate = true
+			raw, quoteStart = raw[1:], quoteStart-1
+		}
+		if i := strings.IndexByte(raw[:quoteStart], ':'); i > 0 && isFieldName(raw[:i]) {
+			t.field, raw = raw[:i], raw[i+1:]
+		}
+		if raw == "" {
+			continue
+		}
+		t.value = raw
+
+		var pattern string
+		switch {
+		case tok.quoted:
+			pattern = "(?i)" + regexp.QuoteMeta(raw)
+		case len(raw) > 2 && raw[0] == '/' && raw[len(raw)-1] == '/': //nolint:mnd
+			pattern = raw[1 : len(raw)-1]
+		case strings.HasPrefix(raw, "^") || strings.HasSuffix(raw, "$"):
+			prefix, suffix := "", ""
+			if rest, ok := strings.CutPrefix(raw, "^"); ok {
+				prefix, raw = "^", rest
+			}
+			if rest, ok := strings.CutSuffix(raw, "$"); ok {
+				suffix, raw = "$", rest
+			}
+			pattern = "(?i)" + prefix + regexp.QuoteMeta(raw) + suffix
+		case t.field == "" && !t.negate:
+			t.fuzzy = true
+		default:
+			pattern = "(?i)" + regexp.QuoteMeta(raw)
+		}
+
+		if !t.fuzzy {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				// Treat invalid expressions, which are common while the
+				// query is being typed, literally.
+				re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(raw))
+			}
+			t.re = re
+		}
+		terms = append(terms, t)
+	}
+	return terms
+}
+
+// queryToken is a whitespace-separated token of a query with quotes removed.
+type queryToken struct {
+	text       string
+	quoted     bool
+	quoteStart int // offset in text of the first quoted character
+}
+
+// splitQuery splits a query at whitespace outside of double quotes.
+func splitQuery(query string) []queryToken {
+	var (
+		tokens []queryToken
+		tok    queryToken
+		b      strings.Builder
+		inTok  bool
+		quoted bool
+	)
+	tok.quoteStart = -1
+
+	flush := func() {
+		if inTok {
+			tok.text = b.String()
+			if tok.quoteStart < 0 {
+				tok.quoteStart = len(tok.text)
+			}
+			tokens = append(tokens, tok)
+		}
+		tok = queryToken{quoteStart: -1}
+		b.Reset()
+		inTok = false
+	}
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inTok = true
+			tok.quoted = true
+			if tok.quoteStart < 0 {
+				tok.quoteStart = b.Len()
+			}
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			inTok = true
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// isFieldName returns whether s can be used as a field name.
+func isFieldName(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// runeIndexes converts byte offsets in s, as reported by sahilm/fuzzy, to rune
+// indices.
+func runeIndexes(s string, offsets []int) []int {
+	indexes := make([]int, len(offsets))
+	for i, b := range offsets {
+		indexes[i] = utf8.RuneCountInString(s[:b])
+	}
+	return indexes
+}
+
+// refinableQuery returns whether query, which extends prev, can only narrow
+// down the items QueryFilter matched for prev. That isn't the case with
+// negated, anchored and regular expression terms: typing more of "-foo"
+// excludes fewer items. Nor is it with field terms, or terms that change kind
+// as they're typed: "status" fuzzy matches the filter value, "status:" is
+// dropped, and "status:open" matches a field.
+func refinableQuery(prev, query string) bool {
+	prevTerms, terms := parseQuery(prev), parseQuery(query)
+	if len(terms) != len(splitQuery(query)) || len(terms) < len(prevTerms) {
+		return false
+	}
+	for i, t := range terms {
+		if !t.refinable() {
+			return false
+		}
+		if i < len(prevTerms) && (!prevTerms[i].refinable() || prevTerms[i].fuzzy != t.fuzzy) {
+			return false
+		}
+	}
+	return true
+}
+
+// refinable returns whether typing more of the term can only narrow down the
+// items it matches.
+func (t queryTerm) refinable() bool {
+	return t.field == "" && !t.negate && !strings.HasPrefix(t.value, "^") &&
+		!strings.HasSuffix(t.value, "$") && !strings.HasPrefix(t.value, "/")
+}
+
+// runeRange returns the rune indices of s between the byte offsets start and
+// end.
+func runeRange(s string, start, end int) []int {
+	first := utf8.RuneCountInString(s[:start])
+	n := utf8.RuneCountInString(s[start:end])
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = first + i
+	}
+	return indexes
+}
@@ -0,0 +1,177 @@
+package list
+
+import (
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// ItemMovedMsg is sent when the user moves an item to reorder the list, so
+// the application can persist the new order.
+type ItemMovedMsg struct {
+	// From and To are the indexes of the item in the unfiltered list of
+	// items before and after the move.
+	From int
+	To   int
+	Item Item
+}
+
+// SetReorderingEnabled enables or disables reordering the list's items with
+// the KeyMap.MoveItem* keybindings and, if MouseEnabled is set, by dragging
+// them with the mouse. Items can only be moved while no filter is applied, and
+// not when they're loaded from a DataSource.
+func (m *Model) SetReorderingEnabled(v bool) {
+	m.reorderingEnabled = v
+	m.updateKeybindings()
+}
+
+// ReorderingEnabled returns whether reordering is enabled.
+func (m Model) ReorderingEnabled() bool {
+	return m.reorderingEnabled
+}
+
+// canReorder returns whether items can be moved right now.
+func (m Model) canReorder() bool {
+	return m.reorderingEnabled && m.dataSource == nil && m.filterState == Unfiltered && len(m.items) > 0
+}
+
+// MoveItem moves the item at index from to index to, both in the unfiltered
+// list of items, shifting the items in between. The cursor and multi-select
+// selections follow their items, and the list is no longer considered sorted.
+// It returns a command that sends an ItemMovedMsg.
+func (m *Model) MoveItem(from, to int) tea.Cmd {
+	if m.dataSource != nil || from < 0 || from >= len(m.items) {
+		return nil
+	}
+	to = clamp(to, 0, len(m.items)-1)
+	if from == to {
+		return nil
+	}
+
+	m.moveItem(from, to)
+	item := m.items[to]
+	cmds := []tea.Cmd{func() tea.Msg {
+		return ItemMovedMsg{From: from, To: to, Item: item}
+	}}
+
+	// Results of filtering in progress refer to the old order.
+	if m.filterState != Unfiltered && m.filterCancel != nil {
+		cmds = append(cmds, m.filterItems())
+	}
+	return tea.Batch(cmds...)
+}
+
+// moveItem moves the item at index from to index to, keeping the cursor on the
+// selected item.
+func (m *Model) moveItem(from, to int) {
+	items := m.VisibleItems()
+	index := m.Index()
+	hasSelected := index >= 0 && index < len(items)
+	selected := m.GlobalIndex()
+
+	newIndex := make([]int, len(m.items))
+	for i := range newIndex {
+		switch {
+		case i == from:
+			newIndex[i] = to
+		case from < to && i > from && i <= to:
+			newIndex[i] = i - 1
+		case to < from && i >= to && i < from:
+			newIndex[i] = i + 1
+		default:
+			newIndex[i] = i
+		}
+	}
+
+	item := m.items[from]
+	m.items = slices.Insert(slices.Delete(slices.Clone(m.items), from, from+1), to, item)
+	m.reindex(newIndex)
+	// The new order becomes the order to return to.
+	m.sortIndex = -1
+	m.unsortedRank = nil
+
+	if hasSelected {
+		selected = newIndex[selected]
+		if m.filterState == Unfiltered {
+			m.Select(selected)
+		} else if i := slices.IndexFunc(m.filteredItems, func(fi filteredItem) bool {
+			return fi.index == selected
+		}); i >= 0 {
+			m.Select(i)
+		}
+	}
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// reindex updates positional selections and filtered items after the items
+// were rearranged, where newIndex maps the old index of each item to its new
+// one.
+func (m *Model) reindex(newIndex []int) {
+	// Positional selections follow their items.
+	if len(m.selected) > 0 {
+		selected := make(map[any]struct{}, len(m.selected))
+		for k := range m.selected {
+			if i, ok := k.(int); ok && i < len(newIndex) {
+				k = newIndex[i]
+			}
+			selected[k] = struct{}{}
+		}
+		m.selected = selected
+	}
+
+	m.filteredItems = slices.Clone(m.filteredItems)
+	for i := range m.filteredItems {
+		if fi := m.filteredItems[i].index; fi < len(newIndex) {
+			m.filteredItems[i].index = newIndex[fi]
+		}
+	}
+}
+
+// moveSelected moves the selected item to index to, returning a command that
+// sends an ItemMovedMsg.
+func (m *Model) moveSelected(to int) tea.Cmd {
+	if !m.canReorder() || m.SelectedItem() == nil {
+		return nil
+	}
+	return m.MoveItem(m.Index(), to)
+}
+
+// startDrag starts dragging the selected item with the mouse.
+func (m *Model) startDrag() {
+	if m.canReorder() {
+		m.dragging = true
+		m.dragFrom = m.Index()
+	}
+}
+
+// drag moves the dragged item to the item rendered at the given coordinates.
+func (m *Model) drag(x, y int) {
+	if !m.dragging || !m.canReorder() {
+		return
+	}
+	index, _, ok := m.itemAt(x, y)
+	if !ok || index == m.Index() {
+		return
+	}
+	m.moveItem(m.Index(), index)
+	m.lastClick = time.Time{}
+}
+
+// drop finishes dragging an item, returning a command that sends an
+// ItemMovedMsg if it moved.
+func (m *Model) drop() tea.Cmd {
+	if !m.dragging {
+		return nil
+	}
+	m.dragging = false
+	from, to := m.dragFrom, m.Index()
+	if from == to || !m.canReorder() {
+		return nil
+	}
+	item := m.SelectedItem()
+	return func() tea.Msg {
+		return ItemMovedMsg{From: from, To: to, Item: item}
+	}
+}
@@ -0,0 +1,133 @@
+package list
+
+import "fmt"
+
+// SetContinuousScrolling enables or disables continuous scrolling. By
+// default the list moves through items a page at a time. With continuous
+// scrolling, the visible window instead slides one item at a time to keep
+// the cursor in view, and the paginator is replaced with a scroll position
+// indicator.
+func (m *Model) SetContinuousScrolling(v bool) {
+	m.continuousScrolling = v
+	m.scrollOffset = 0
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// ContinuousScrolling returns whether continuous scrolling is enabled.
+func (m Model) ContinuousScrolling() bool {
+	return m.continuousScrolling
+}
+
+// SetScrollOff sets the minimum number of items to keep visible above and
+// below the cursor when continuous scrolling is enabled.
+func (m *Model) SetScrollOff(v int) {
+	m.scrollOff = max(0, v)
+	m.scrollToCursor()
+}
+
+// scrolling returns whether the list scrolls continuously. Grids are always
+// paginated.
+func (m Model) scrolling() bool {
+	return m.continuousScrolling && !m.isGrid()
+}
+
+// ScrollOff returns the minimum number of items kept visible above and below
+// the cursor when continuous scrolling is enabled.
+func (m Model) ScrollOff() int {
+	return m.scrollOff
+}
+
+// itemHeight returns the height of the item at the given index in the
+// filtered list of items, not including its group header. See rowHeight.
+func (m Model) itemHeight(index int, item Item) int {
+	if d, ok := m.delegate.(VariableHeightDelegate); ok && item != nil {
+		return max(1, d.ItemHeight(index, item))
+	}
+	return max(1, m.delegate.Height())
+}
+
+// fits returns whether the items from first through last, inclusive, fit in
+// the given height.
+func (m Model) fits(items []Item, first, last, height int) bool {
+	used := 0
+	for i := first; i <= last && i < len(items); i++ {
+		h := m.rowHeight(items, i)
+		if h == 0 {
+			continue
+		}
+		if used > 0 {
+			used += m.delegate.Spacing()
+		}
+		used += h
+		if used > height {
+			return false
+		}
+	}
+	return true
+}
+
+// scrollToCursor slides the visible window in continuous scrolling mode so
+// that the cursor, along with scrollOff items around it, is visible.
+func (m *Model) scrollToCursor() {
+	if !m.scrolling() {
+		return
+	}
+
+	items := m.VisibleItems()
+	if len(items) == 0 {
+		m.scrollOffset = 0
+		return
+	}
+
+	index := clamp(m.Index(), 0, len(items)-1)
+	margin := m.scrollOff
+	if h := m.delegate.Height() + m.delegate.Spacing(); h > 0 {
+		// The margin can't exceed half of what fits on screen.
+		margin = min(margin, max(0, m.itemsHeight/h-1)/2) //nolint:mnd
+	}
+
+	// Scroll up.
+	m.scrollOffset = clamp(m.scrollOffset, 0, len(items)-1)
+	if first := max(0, index-margin); first < m.scrollOffset {
+		m.scrollOffset = first
+	}
+
+	// Scroll down.
+	last := min(index+margin, len(items)-1)
+	for m.scrollOffset < index && !m.fits(items, m.scrollOffset, last, m.itemsHeight) {
+		m.scrollOffset++
+	}
+
+	// Don't leave empty space below the last item.
+	for m.scrollOffset > 0 && m.fits(items, m.scrollOffset-1, len(items)-1, m.itemsHeight) {
+		m.scrollOffset--
+	}
+}
+
+// visibleRange returns the range of items shown in continuous scrolling mode.
+func (m Model) visibleRange(items []Item) (start, end int) {
+	start = clamp(m.scrollOffset, 0, len(items))
+	end = start
+	for end < len(items) && (end == start || m.fits(items, start, end, m.itemsHeight)) {
+		end++
+	}
+	return start, end
+}
+
+// scrollView renders the scroll position indicator shown in place of the
+// paginator in continuous scrolling mode.
+func (m Model) scrollView() string {
+	items := m.VisibleItems()
+	if len(items) == 0 {
+		return ""
+	}
+	return m.Styles.ArabicPagination.Render(fmt.Sprintf("%d/%d", m.Index()+1, len(items)))
+}
+
+// screenful returns the number of items shown at once in continuous
+// scrolling mode.
+func (m Model) screenful() int {
+	start, end := m.visibleRange(m.VisibleItems())
+	return max(1, end-start)
+}
@@ -0,0 +1,223 @@
+package list
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// SortOrder is a named order that the list's items can be sorted in. See
+// Model.SetSortOrders.
+type SortOrder struct {
+	// Name describes the order in the status bar, such as "name" or "date".
+	Name string
+
+	// Compare returns a negative number when a sorts before b, a positive
+	// number when a sorts after b, and zero when they're equal. Equal items
+	// keep their relative order.
+	Compare func(a, b Item) int
+}
+
+// SetSortOrders sets the orders the user can sort the list in by cycling
+// through them with KeyMap.CycleSortOrder. The items are left unsorted until
+// an order is chosen, so if they're sorted, they return to the order they
+// were given in. This returns a command.
+func (m *Model) SetSortOrders(orders ...SortOrder) tea.Cmd {
+	m.sortIndex = -1
+	cmd := m.resort()
+	m.sortOrders = orders
+	m.updateKeybindings()
+	return cmd
+}
+
+// SortOrders returns the orders the list can be sorted in.
+func (m Model) SortOrders() []SortOrder {
+	return m.sortOrders
+}
+
+// SortOrder returns the order the list is currently sorted in, if any.
+func (m Model) SortOrder() (SortOrder, bool) {
+	if m.sortIndex < 0 || m.sortIndex >= len(m.sortOrders) {
+		return SortOrder{}, false
+	}
+	return m.sortOrders[m.sortIndex], true
+}
+
+// SortDescending returns whether the list is sorted in descending order.
+func (m Model) SortDescending() bool {
+	return m.sortDescending
+}
+
+// SetSortOrder sorts the list in the order at the given index of the sort
+// orders. An index out of range stops sorting, returning the items to the
+// order they were given in. This returns a command.
+func (m *Model) SetSortOrder(index int, descending bool) tea.Cmd {
+	if index < 0 || index >= len(m.sortOrders) {
+		index = -1
+	}
+	m.sortIndex = index
+	m.sortDescending = descending
+	return m.resort()
+}
+
+// CycleSortOrder sorts the list in the next sort order. After the last one,
+// the items return to the order they were given in. This returns a command.
+func (m *Model) CycleSortOrder() tea.Cmd {
+	if len(m.sortOrders) == 0 {
+		return nil
+	}
+	m.sortIndex++
+	if m.sortIndex >= len(m.sortOrders) {
+		m.sortIndex = -1
+	}
+	return m.resort()
+}
+
+// ToggleSortDirection switches between sorting in ascending and descending
+// order. This returns a command.
+func (m *Model) ToggleSortDirection() tea.Cmd {
+	m.sortDescending = !m.sortDescending
+	return m.resort()
+}
+
+// compareItems compares two items in the current sort order and direction.
+func (m Model) compareItems(order SortOrder, a, b Item) int {
+	if m.sortDescending {
+		return order.Compare(b, a)
+	}
+	return order.Compare(a, b)
+}
+
+// resort sorts the items after the sort order changed, keeping the cursor on
+// the selected item.
+func (m *Model) resort() tea.Cmd {
+	items := m.VisibleItems()
+	index := m.Index()
+	var selected filteredItem
+	hasSelected := index >= 0 && index < len(items)
+	if hasSelected {
+		selected = filteredItem{index: m.GlobalIndex(), item: items[index]}
+	}
+
+	newIndex := m.sortItems()
+
+	if hasSelected {
+		if newIndex != nil {
+			selected.index = newIndex[selected.index]
+		}
+		if m.filterState == Unfiltered {
+			m.Select(selected.index)
+		} else if i := slices.IndexFunc(m.filteredItems, func(fi filteredItem) bool {
+			return fi.index == selected.index
+		}); i >= 0 {
+			m.Select(i)
+		}
+	}
+	m.updateKeybindings()
+
+	// Results of filtering in progress refer to the old order.
+	if newIndex != nil && m.filterState != Unfiltered && m.filterCancel != nil {
+		return m.filterItems()
+	}
+	return nil
+}
+
+// sortItems sorts the items in the current sort order, or returns them to the
+// order they were given in when they're no longer sorted, and returns where
+// each item moved to. It returns nil if the items didn't move.
+func (m *Model) sortItems() []int {
+	if m.dataSource != nil {
+		return nil
+	}
+	order, sorted := m.SortOrder()
+	sorted = sorted && order.Compare != nil
+	if !sorted && m.unsortedRank == nil {
+		return nil
+	}
+	if m.unsortedRank == nil {
+		m.unsortedRank = make([]int, len(m.items))
+		for i := range m.unsortedRank {
+			m.unsortedRank[i] = i
+		}
+	}
+
+	// Equal items keep the order they were given in.
+	perm := make([]int, len(m.items))
+	for i := range perm {
+		perm[i] = i
+	}
+	slices.SortFunc(perm, func(a, b int) int {
+		if sorted {
+			if c := m.compareItems(order, m.items[a], m.items[b]); c != 0 {
+				return c
+			}
+		}
+		return m.unsortedRank[a] - m.unsortedRank[b]
+	})
+
+	items := make([]Item, len(perm))
+	rank := make([]int, len(perm))
+	newIndex := make([]int, len(perm))
+	for i, old := range perm {
+		items[i] = m.items[old]
+		rank[i] = m.unsortedRank[old]
+		newIndex[old] = i
+	}
+	m.items = items
+	m.unsortedRank = rank
+	if !sorted {
+		m.unsortedRank = nil
+	}
+	m.reindex(newIndex)
+	m.sortFilteredItems()
+
+	return newIndex
+}
+
+// insertRank makes room for an item inserted at the given index while the
+// items are sorted. In the order the items were given in, it goes before the
+// item it's inserted before.
+func (m *Model) insertRank(index int) {
+	if m.unsortedRank == nil {
+		return
+	}
+	index = clamp(index, 0, len(m.unsortedRank))
+	var rank int
+	if index < len(m.unsortedRank) {
+		rank = m.unsortedRank[index]
+	} else if len(m.unsortedRank) > 0 {
+		// Appended items go last.
+		rank = slices.Max(m.unsortedRank) + 1
+	}
+	ranks := make([]int, 0, len(m.unsortedRank)+1)
+	for _, r := range m.unsortedRank {
+		if r >= rank {
+			r++
+		}
+		ranks = append(ranks, r)
+	}
+	m.unsortedRank = slices.Insert(ranks, index, rank)
+}
+
+// removeRank forgets the position of an item removed while the items are
+// sorted.
+func (m *Model) removeRank(index int) {
+	if m.unsortedRank == nil {
+		return
+	}
+	m.unsortedRank = slices.Delete(slices.Clone(m.unsortedRank), index, index+1)
+}
+
+// sortFilteredItems sorts filtered items in the current sort order if
+// SortFilterResults is set. Otherwise they keep the order the filter ranked
+// them in.
+func (m *Model) sortFilteredItems() {
+	order, ok := m.SortOrder()
+	if !ok || order.Compare == nil || !m.SortFilterResults {
+		return
+	}
+	m.filteredItems = slices.Clone(m.filteredItems)
+	slices.SortStableFunc(m.filteredItems, func(a, b filteredItem) int {
+		return m.compareItems(order, a.item, b.item)
+	})
+}
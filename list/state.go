@@ -0,0 +1,120 @@
+package list
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"charm.land/bubbles/v2/textinput"
+)
+
+// State is a snapshot of a list's view state: where the cursor is, and how
+// the items are filtered, sorted and grouped. It can be marshaled to JSON to
+// restore the list later, such as when the application restarts. See
+// Model.State and Model.Restore.
+type State struct {
+	// Page and Cursor are the current page, and the index of the cursor on
+	// that page.
+	Page   int `json:"page"`
+	Cursor int `json:"cursor"`
+
+	// ScrollOffset is the index of the first visible item with continuous
+	// scrolling.
+	ScrollOffset int `json:"scroll_offset,omitempty"`
+
+	// ItemID is the ID of the selected item, if it's an IdentifiableItem.
+	// When set, the cursor is restored to the item wherever it is.
+	ItemID string `json:"item_id,omitempty"`
+
+	FilterText  string      `json:"filter_text,omitempty"`
+	FilterState FilterState `json:"filter_state"`
+
+	// SortOrder is the name of the order the items are sorted in, if any.
+	SortOrder      string `json:"sort_order,omitempty"`
+	SortDescending bool   `json:"sort_descending,omitempty"`
+
+	CollapsedGroups []string `json:"collapsed_groups,omitempty"`
+}
+
+// State returns a snapshot of the list's view state.
+func (m Model) State() State {
+	s := State{
+		Page:           m.Paginator.Page,
+		Cursor:         m.cursor,
+		ScrollOffset:   m.scrollOffset,
+		FilterText:     m.FilterInput.Value(),
+		FilterState:    m.filterState,
+		SortDescending: m.sortDescending,
+	}
+	if i, ok := m.cursorItem().(IdentifiableItem); ok {
+		s.ItemID = i.ID()
+	}
+	if order, ok := m.SortOrder(); ok {
+		s.SortOrder = order.Name
+	}
+	for group := range m.collapsed {
+		s.CollapsedGroups = append(s.CollapsedGroups, group)
+	}
+	slices.Sort(s.CollapsedGroups)
+	return s
+}
+
+// Restore reapplies a state returned by State, typically after the items have
+// been loaded again. The filter is run again on the current items, and the
+// cursor is moved to the item it was on if the item is identifiable, or to the
+// same position otherwise, clamped to the items there now. Unknown sort
+// orders are ignored. This returns a command.
+func (m *Model) Restore(s State) tea.Cmd {
+	m.resetFiltering()
+
+	m.sortIndex = slices.IndexFunc(m.sortOrders, func(order SortOrder) bool {
+		return order.Name == s.SortOrder
+	})
+	m.sortDescending = s.SortDescending
+	m.sortItems()
+
+	m.collapsed = nil
+	for _, group := range s.CollapsedGroups {
+		if m.collapsed == nil {
+			m.collapsed = make(map[string]struct{})
+		}
+		m.collapsed[group] = struct{}{}
+	}
+	m.updatePagination()
+
+	var cmd tea.Cmd
+	if s.FilterState != Unfiltered && m.filteringEnabled && (s.FilterText != "" || s.FilterState == Filtering) {
+		m.SetFilterText(s.FilterText)
+		if s.FilterState == Filtering {
+			m.filterState = Filtering
+			m.FilterInput.Focus()
+			cmd = textinput.Blink
+		}
+	}
+
+	m.restoreCursor(s)
+	m.updateKeybindings()
+	return cmd
+}
+
+// restoreCursor moves the cursor to where it was in the given state.
+func (m *Model) restoreCursor(s State) {
+	items := m.VisibleItems()
+	if s.ItemID != "" {
+		if i := slices.IndexFunc(items, func(item Item) bool {
+			id, ok := item.(IdentifiableItem)
+			return ok && id.ID() == s.ItemID
+		}); i >= 0 {
+			m.scrollOffset = s.ScrollOffset
+			m.Select(i)
+			m.skipCollapsed()
+			return
+		}
+	}
+
+	m.Paginator.Page = clamp(s.Page, 0, max(0, m.Paginator.TotalPages-1))
+	m.cursor = clamp(s.Cursor, 0, m.maxCursorIndex())
+	m.scrollOffset = s.ScrollOffset
+	m.skipCollapsed()
+	m.scrollToCursor()
+}
@@ -6,8 +6,10 @@ import (
 )
 
 const (
-	bullet   = "•"
-	ellipsis = "…"
+	bullet        = "•"
+	ellipsis      = "…"
+	expandedMark  = "▾"
+	collapsedMark = "▸"
 )
 
 // Styles contains style definitions for this list component. By default, these
@@ -26,9 +28,27 @@ type Styles struct {
 	StatusEmpty           lipgloss.Style
 	StatusBarActiveFilter lipgloss.Style
 	StatusBarFilterCount  lipgloss.Style
+	StatusBarSortOrder    lipgloss.Style
 
 	NoItems lipgloss.Style
 
+	// Placeholder is used for items that are still loading from a
+	// DataSource.
+	Placeholder lipgloss.Style
+
+	// Headers of grouped items. SelectedGroupHeader is used for the header of
+	// a collapsed group when the cursor is on it.
+	GroupHeader         lipgloss.Style
+	SelectedGroupHeader lipgloss.Style
+
+	// The action menu. ActionMenuOffset is how far from the left edge of
+	// the selected item the menu is drawn.
+	ActionMenu             lipgloss.Style
+	ActionMenuItem         lipgloss.Style
+	SelectedActionMenuItem lipgloss.Style
+	ActionMenuKey          lipgloss.Style
+	ActionMenuOffset       int
+
 	PaginationStyle lipgloss.Style
 	HelpStyle       lipgloss.Style
 
@@ -77,9 +97,38 @@ func DefaultStyles(isDark bool) (s Styles) {
 
 	s.StatusBarFilterCount = lipgloss.NewStyle().Foreground(verySubduedColor)
 
+	s.StatusBarSortOrder = lipgloss.NewStyle().Foreground(verySubduedColor)
+
 	s.NoItems = lipgloss.NewStyle().
 		Foreground(lightDark(lipgloss.Color("#909090"), lipgloss.Color("#626262")))
 
+	s.Placeholder = lipgloss.NewStyle().
+		Foreground(subduedColor).
+		Padding(0, 0, 0, 2) //nolint:mnd
+
+	s.GroupHeader = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lightDark(lipgloss.Color("#847A85"), lipgloss.Color("#979797"))).
+		Padding(0, 0, 0, 2) //nolint:mnd
+
+	s.SelectedGroupHeader = s.GroupHeader.
+		Foreground(lightDark(lipgloss.Color("#EE6FF8"), lipgloss.Color("#EE6FF8")))
+
+	s.ActionMenu = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lightDark(lipgloss.Color("#F793FF"), lipgloss.Color("#AD58B4")))
+
+	s.ActionMenuItem = lipgloss.NewStyle().
+		Foreground(lightDark(lipgloss.Color("#1a1a1a"), lipgloss.Color("#dddddd"))).
+		Padding(0, 1)
+
+	s.SelectedActionMenuItem = s.ActionMenuItem.
+		Foreground(lightDark(lipgloss.Color("#EE6FF8"), lipgloss.Color("#EE6FF8")))
+
+	s.ActionMenuKey = lipgloss.NewStyle().Foreground(subduedColor)
+
+	s.ActionMenuOffset = 2
+
 	s.ArabicPagination = lipgloss.NewStyle().Foreground(subduedColor)
 
 	s.PaginationStyle = lipgloss.NewStyle().PaddingLeft(2) //nolint:mnd
@@ -0,0 +1,80 @@
+package list
+
+import (
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/textinput"
+)
+
+// SetStyles sets the list's styles, including the styles of its spinner and
+// paginator. Styles set this way are kept when the dark mode changes, see
+// SetDarkMode.
+func (m *Model) SetStyles(s Styles) {
+	m.Styles = s
+	m.spinner.Style = s.Spinner
+	m.Paginator.ActiveDot = s.ActivePaginationDot.String()
+	m.Paginator.InactiveDot = s.InactivePaginationDot.String()
+	m.customStyles = true
+	m.updatePagination()
+}
+
+// SetDarkMode switches the list between its light and dark default styles,
+// along with the default styles of its help, paginator, spinner and filter
+// input, unless the styles were set with SetStyles. A DefaultDelegate is
+// switched too, unless its styles were set with DefaultDelegate.SetStyles.
+//
+// Styles assigned directly, such as to Styles or Help.Styles, are replaced
+// by the defaults when the mode changes. Use SetStyles to keep them.
+//
+// The list calls this itself when it receives a tea.BackgroundColorMsg, which
+// the application can request with tea.RequestBackgroundColor. Until then,
+// the dark styles are used.
+func (m *Model) SetDarkMode(isDark bool) {
+	if isDark == m.isDark {
+		return
+	}
+	m.isDark = isDark
+
+	if !m.customStyles {
+		styles := DefaultStyles(isDark)
+		m.Styles = styles
+		m.spinner.Style = styles.Spinner
+		m.Paginator.ActiveDot = styles.ActivePaginationDot.String()
+		m.Paginator.InactiveDot = styles.InactivePaginationDot.String()
+		m.FilterInput.SetStyles(textinput.DefaultStyles(isDark))
+		m.Help.Styles = help.DefaultStyles(isDark)
+	}
+
+	switch d := m.delegate.(type) {
+	case DefaultDelegate:
+		d.SetDarkMode(isDark)
+		m.delegate = d
+	case *DefaultDelegate:
+		d.SetDarkMode(isDark)
+	}
+
+	m.updatePagination()
+}
+
+// DarkMode returns whether the list uses its dark styles.
+func (m Model) DarkMode() bool {
+	return m.isDark
+}
+
+// SetStyles sets the delegate's styles. Styles set this way are kept when the
+// dark mode changes.
+func (d *DefaultDelegate) SetStyles(s DefaultItemStyles) {
+	d.Styles = s
+	d.customStyles = true
+}
+
+// SetDarkMode switches the delegate between its light and dark default
+// styles, unless they were set with SetStyles.
+func (d *DefaultDelegate) SetDarkMode(isDark bool) {
+	if isDark == d.isDark {
+		return
+	}
+	if !d.customStyles {
+		d.Styles = NewDefaultItemStyles(isDark)
+	}
+	d.isDark = isDark
+}
@@ -0,0 +1,192 @@
+package table
+
+import "slices"
+
+// Cell is a cell of the table.
+type Cell struct {
+	// Row is the index of the cell's row in Rows, and Column the index of
+	// its column in Columns.
+	Row    int
+	Column int
+	Value  string
+}
+
+// WithCellSelection enables or disables the cell cursor. See
+// Model.SetCellSelection.
+func WithCellSelection(v bool) Option {
+	return func(m *Model) {
+		m.cellSelection = v
+	}
+}
+
+// SetCellSelection enables or disables the cell cursor. With the cell cursor,
+// the user moves between the cells of the selected row with the CellLeft,
+// CellRight, NextCell and PrevCell keybindings, the focused cell is
+// highlighted with the SelectedCell style, and the table scrolls
+// horizontally to keep it visible.
+func (m *Model) SetCellSelection(v bool) {
+	m.cellSelection = v
+	m.editing = m.editing && v
+	m.setColumnCursor(m.colCursor)
+	m.updateKeybindings()
+	m.UpdateViewport()
+}
+
+// CellSelection returns whether the cell cursor is enabled.
+func (m Model) CellSelection() bool {
+	return m.cellSelection
+}
+
+// ColumnCursor returns the index of the column of the focused cell.
+func (m Model) ColumnCursor() int {
+	return m.colCursor
+}
+
+// SetColumnCursor sets the column of the focused cell. If the column is
+// hidden, the next visible column is focused instead.
+func (m *Model) SetColumnCursor(n int) {
+	m.setColumnCursor(n)
+	m.UpdateViewport()
+}
+
+// setColumnCursor sets the column of the focused cell, moving it off hidden
+// columns to the next visible column, or the previous one if there's none.
+func (m *Model) setColumnCursor(n int) {
+	m.colCursor = clamp(n, 0, max(0, len(m.cols)-1))
+	if m.colCursor >= len(m.cols) || m.cols[m.colCursor].Width > 0 {
+		return
+	}
+	if next, ok := m.visibleColumn(m.colCursor, 1); ok {
+		m.colCursor = next
+	} else if prev, ok := m.visibleColumn(m.colCursor, -1); ok {
+		m.colCursor = prev
+	}
+}
+
+// SelectedCell returns the focused cell. It returns false if there's no
+// focused cell, such as when the table is empty or the cell cursor is
+// disabled.
+func (m Model) SelectedCell() (Cell, bool) {
+	row := m.SelectedRow()
+	if !m.cellSelection || row == nil || m.colCursor < 0 || m.colCursor >= len(m.cols) {
+		return Cell{}, false
+	}
+	cell := Cell{Row: m.cursor, Column: m.colCursor}
+	if m.colCursor < len(row) {
+		cell.Value = row[m.colCursor]
+	}
+	return cell, true
+}
+
+// MoveLeft moves the cell cursor left by any number of visible columns. It
+// can not go past the first column.
+func (m *Model) MoveLeft(n int) {
+	for ; n > 0; n-- {
+		prev, ok := m.visibleColumn(m.colCursor, -1)
+		if !ok {
+			break
+		}
+		m.colCursor = prev
+	}
+	m.UpdateViewport()
+}
+
+// MoveRight moves the cell cursor right by any number of visible columns. It
+// can not go past the last column.
+func (m *Model) MoveRight(n int) {
+	for ; n > 0; n-- {
+		next, ok := m.visibleColumn(m.colCursor, 1)
+		if !ok {
+			break
+		}
+		m.colCursor = next
+	}
+	m.UpdateViewport()
+}
+
+// nextCell moves the cell cursor to the next cell, continuing on the next row
+// from the last column.
+func (m *Model) nextCell() {
+	if next, ok := m.visibleColumn(m.colCursor, 1); ok {
+		m.colCursor = next
+		m.UpdateViewport()
+		return
+	}
+	if m.cursor < len(m.rows)-1 {
+		if first, ok := m.visibleColumn(-1, 1); ok {
+			m.colCursor = first
+		}
+		m.MoveDown(1)
+	}
+}
+
+// prevCell moves the cell cursor to the previous cell, continuing on the
+// previous row from the first column.
+func (m *Model) prevCell() {
+	if prev, ok := m.visibleColumn(m.colCursor, -1); ok {
+		m.colCursor = prev
+		m.UpdateViewport()
+		return
+	}
+	if m.cursor > 0 {
+		if last, ok := m.visibleColumn(len(m.cols), -1); ok {
+			m.colCursor = last
+		}
+		m.MoveUp(1)
+	}
+}
+
+// visibleColumn returns the first column with a positive width from the given
+// column in the given direction, not including the column itself.
+func (m Model) visibleColumn(from, direction int) (int, bool) {
+	for i := from + direction; i >= 0 && i < len(m.cols); i += direction {
+		if m.cols[i].Width > 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// columnBounds returns where the given column starts and ends horizontally.
+func (m Model) columnBounds(column int) (start, end int) {
+	frame := m.styles.Cell.GetHorizontalFrameSize()
+	for i, col := range m.cols {
+		if col.Width <= 0 {
+			continue
+		}
+		w := col.Width + frame
+		if i == column {
+			return start, start + w
+		}
+		start += w
+	}
+	return start, start
+}
+
+// scrollToColumn scrolls horizontally so that the focused column is visible.
+func (m *Model) scrollToColumn() {
+	// Frozen columns are always visible, and the other columns scroll in
+	// the space left.
+	frozen := m.frozenWidth()
+	width := m.scrollWidth()
+	if !m.cellSelection || width <= 0 || m.colCursor < m.frozenColumns() {
+		return
+	}
+	start, end := m.columnBounds(m.colCursor)
+	start, end = start-frozen, end-frozen
+	offset := m.viewport.XOffset()
+	if end > offset+width {
+		offset = end - width
+		if m.scrolls() {
+			// Scroll to the start of a column, as ScrollRight does.
+			starts, _ := m.columnStarts()
+			if i, _ := slices.BinarySearch(starts, offset); i < len(starts) {
+				offset = min(starts[i], start)
+			}
+		}
+	}
+	if start < offset {
+		offset = start
+	}
+	m.viewport.SetXOffset(offset)
+}
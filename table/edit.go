@@ -0,0 +1,180 @@
+package table
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+)
+
+// CellEditedMsg is sent when the user commits an edit of a cell. The table
+// has already updated the cell. To reject the change, set the cell back to
+// OldValue with SetCell.
+type CellEditedMsg struct {
+	// Row is the index of the cell's row in Rows, and Column the index of
+	// its column in Columns, after the change. If the rows are sorted, the
+	// row may have moved.
+	Row      int
+	Column   int
+	OldValue string
+	NewValue string
+}
+
+// Edit starts editing the focused cell, if its column is editable. Editing
+// requires the cell cursor, see SetCellSelection. This returns a command.
+func (m *Model) Edit() tea.Cmd {
+	cell, ok := m.SelectedCell()
+	if !ok || !m.cols[cell.Column].Editable {
+		return nil
+	}
+
+	m.editor = textinput.New()
+	m.editor.Prompt = ""
+	m.editor.Validate = m.cols[cell.Column].Validate
+	m.editor.AsyncValidate = m.cols[cell.Column].AsyncValidate
+	m.editor.SetWidth(m.cols[cell.Column].Width - 1)
+	m.editor.SetValue(cell.Value)
+	m.editor.CursorEnd()
+	m.editing = true
+	m.updateKeybindings()
+	cmd := m.editor.Focus()
+	m.UpdateViewport()
+	return cmd
+}
+
+// Editing returns whether a cell is being edited.
+func (m Model) Editing() bool {
+	return m.editing
+}
+
+// EditError returns the error returned by the edited column's Validate
+// function for the value being edited, if any.
+func (m Model) EditError() error {
+	if !m.editing {
+		return nil
+	}
+	return m.editor.Err
+}
+
+// CancelEdit stops editing, leaving the cell unchanged.
+func (m *Model) CancelEdit() {
+	m.editing = false
+	m.committing = false
+	m.updateKeybindings()
+	m.editor.Blur()
+	m.UpdateViewport()
+}
+
+// CommitEdit stops editing and sets the cell to the edited value, unless the
+// value is invalid, in which case editing continues. It returns a command that
+// sends a CellEditedMsg if the value changed. If the column has an
+// AsyncValidate function, the command validates the value first, and the
+// edit is committed once it passes.
+func (m *Model) CommitEdit() tea.Cmd {
+	if _, ok := m.SelectedCell(); !m.editing || !ok {
+		m.CancelEdit()
+		return nil
+	}
+	m.committing = false
+	cmd := m.editor.RunValidation()
+	if m.editor.Err == nil && m.editor.Validating() {
+		m.committing = true
+		m.UpdateViewport()
+		return cmd
+	}
+	return m.commit()
+}
+
+// commit sets the cell to the edited value once it's been validated.
+func (m *Model) commit() tea.Cmd {
+	cell, ok := m.SelectedCell()
+	if !ok {
+		m.CancelEdit()
+		return nil
+	}
+	if m.editor.Err != nil {
+		m.UpdateViewport()
+		return nil
+	}
+
+	value := m.editor.Value()
+	m.CancelEdit()
+	if value == cell.Value {
+		return nil
+	}
+	m.SetCell(cell.Row, cell.Column, value)
+	row := m.cursor
+	return func() tea.Msg {
+		return CellEditedMsg{Row: row, Column: cell.Column, OldValue: cell.Value, NewValue: value}
+	}
+}
+
+// SetCell sets the value of the cell at the given row, in Rows, and column.
+// The row is copied rather than modified in place. If the rows are sorted,
+// they're sorted again, keeping the cursor on the selected row.
+func (m *Model) SetCell(row, column int, value string) {
+	if row < 0 || row >= len(m.rows) || column < 0 || column >= len(m.cols) {
+		return
+	}
+
+	r := slices.Clone(m.rows[row])
+	if column >= len(r) {
+		r = append(r, make(Row, column-len(r)+1)...)
+	}
+	r[column] = value
+
+	m.rows = slices.Clone(m.rows)
+	m.rows[row] = r
+	if m.fitsContent() {
+		m.layoutColumns()
+	}
+	if m.order != nil {
+		m.unsorted = slices.Clone(m.unsorted)
+		m.unsorted[m.order[row]] = r
+		m.resort()
+		return
+	}
+	m.UpdateViewport()
+}
+
+// handleEditing handles messages while a cell is being edited.
+func (m *Model) handleEditing(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.CommitEdit):
+			return m.CommitEdit()
+		case key.Matches(msg, m.KeyMap.CancelEdit):
+			m.CancelEdit()
+			return nil
+		}
+		// Changing the value drops the validation in progress, so the
+		// edit has to be committed again.
+		m.committing = false
+
+	case textinput.ValidationMsg:
+		m.editor, _ = m.editor.Update(msg)
+		if m.committing && !m.editor.Validating() {
+			m.committing = false
+			return m.commit()
+		}
+		m.UpdateViewport()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	m.UpdateViewport()
+	return cmd
+}
+
+// editorView renders the editor in place of the focused cell.
+func (m Model) editorView() string {
+	view := m.editor.View()
+	if m.editor.Err != nil {
+		return m.styles.InvalidCell.Render(view)
+	}
+	return view
+}
@@ -0,0 +1,143 @@
+package table
+
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
+
+// flexible returns whether the table sets the column's width.
+func (c Column) flexible() bool {
+	return c.Flex > 0 || c.Auto
+}
+
+// bound clamps the given width between the column's MinWidth and MaxWidth.
+// The width is at least 1, since a width of zero hides the column.
+func (c Column) bound(w int) int {
+	if c.MaxWidth > 0 {
+		w = min(w, c.MaxWidth)
+	}
+	return max(w, c.MinWidth, 1)
+}
+
+// fitsContent returns whether the width of any column depends on the values
+// of the rows, which is the case for auto-sized columns, and for flexible
+// columns when the table has no width.
+func (m Model) fitsContent() bool {
+	return slices.ContainsFunc(m.cols, func(c Column) bool {
+		return c.Auto || (c.Flex > 0 && m.viewport.Width() <= 0)
+	})
+}
+
+// layoutColumns sets the widths of flexible and auto-sized columns. Without a
+// table width, flexible columns are sized to fit their content.
+func (m *Model) layoutColumns() {
+	if !slices.ContainsFunc(m.cols, Column.flexible) {
+		return
+	}
+	m.cols = slices.Clone(m.cols)
+
+	frame := m.styles.Cell.GetHorizontalFrameSize()
+	width := m.viewport.Width()
+	space := width
+	var flex []int
+	for i, col := range m.cols {
+		switch {
+		case col.Flex > 0 && width > 0:
+			flex = append(flex, i)
+			space -= frame
+			continue
+		case col.flexible():
+			m.cols[i].Width = col.bound(m.contentWidth(i))
+		}
+		if m.cols[i].Width > 0 {
+			space -= m.cols[i].Width + frame
+		}
+	}
+
+	// Share the space left between flexible columns by weight. When a
+	// column's bounds don't allow its share, it gets the nearest bound and
+	// the rest is shared again between the others.
+	for len(flex) > 0 {
+		shares := share(max(0, space), flex, m.cols)
+		clamped := false
+		for j, i := range flex {
+			if w := m.cols[i].bound(shares[j]); w != shares[j] {
+				m.cols[i].Width = w
+				space -= w
+				flex = slices.Delete(flex, j, j+1)
+				clamped = true
+				break
+			}
+		}
+		if !clamped {
+			for j, i := range flex {
+				m.cols[i].Width = shares[j]
+			}
+			break
+		}
+	}
+}
+
+// share divides the given space between the given columns in proportion to
+// their Flex.
+func share(space int, flex []int, cols []Column) []int {
+	total := 0
+	for _, i := range flex {
+		total += cols[i].Flex
+	}
+	shares := make([]int, len(flex))
+	left := space
+	for j, i := range flex {
+		shares[j] = space * cols[i].Flex / total
+		left -= shares[j]
+	}
+	// Hand out what's left over from rounding down from the left.
+	for j := 0; left > 0; j = (j + 1) % len(shares) {
+		shares[j]++
+		left--
+	}
+	return shares
+}
+
+// contentWidth returns the width of the widest of the column's title and
+// values.
+func (m Model) contentWidth(column int) int {
+	w := lipgloss.Width(m.cols[column].Title)
+	for _, row := range m.rows {
+		if column < len(row) {
+			w = max(w, lipgloss.Width(row[column]))
+		}
+	}
+	return w
+}
+
+// wraps returns whether any visible column wraps its values.
+func (m Model) wraps() bool {
+	return slices.ContainsFunc(m.cols, func(c Column) bool {
+		return c.Wrap && c.Width > 0
+	})
+}
+
+// scrollToCursor scrolls vertically so that the selected row is visible,
+// given the rendered rows from m.start. Rows may take up several lines when
+// values wrap.
+func (m *Model) scrollToCursor(renderedRows []string) {
+	if m.cursor < m.start || m.cursor >= m.end {
+		return
+	}
+	top := 0
+	for _, row := range renderedRows[:m.cursor-m.start] {
+		top += lipgloss.Height(row)
+	}
+	bottom := top + lipgloss.Height(renderedRows[m.cursor-m.start])
+
+	offset := m.viewport.YOffset()
+	if bottom > offset+m.viewport.Height() {
+		offset = bottom - m.viewport.Height()
+	}
+	if top < offset {
+		offset = top
+	}
+	m.viewport.SetYOffset(offset)
+}
@@ -0,0 +1,241 @@
+package table
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"charm.land/bubbles/v2/viewport"
+)
+
+const (
+	scrollLeftIndicator  = "‹"
+	scrollRightIndicator = "›"
+)
+
+// WithFrozenColumns freezes the given number of leading columns, see
+// SetFrozenColumns.
+func WithFrozenColumns(n int) Option {
+	return func(m *Model) {
+		m.frozen = max(0, n)
+	}
+}
+
+// SetFrozenColumns freezes the given number of leading columns, so they stay
+// in place while the other columns scroll horizontally. Freezing columns
+// enables horizontal scrolling, see SetHorizontalScrolling.
+func (m *Model) SetFrozenColumns(n int) {
+	m.frozen = max(0, n)
+	m.updateKeybindings()
+	m.UpdateViewport()
+}
+
+// FrozenColumns returns the number of leading columns that don't scroll
+// horizontally.
+func (m Model) FrozenColumns() int {
+	return m.frozen
+}
+
+// WithHorizontalScrolling lets the user scroll the columns horizontally, see
+// SetHorizontalScrolling.
+func WithHorizontalScrolling(v bool) Option {
+	return func(m *Model) {
+		m.horizontalScrolling = v
+	}
+}
+
+// SetHorizontalScrolling sets whether the user can scroll the columns
+// horizontally, with the ScrollLeft and ScrollRight keybindings and the mouse
+// wheel. Indicators in the headers show when there are more columns to either
+// side. It's always enabled when columns are frozen.
+func (m *Model) SetHorizontalScrolling(v bool) {
+	m.horizontalScrolling = v
+	m.updateKeybindings()
+	m.UpdateViewport()
+}
+
+// HorizontalScrolling returns whether the user can scroll the columns
+// horizontally.
+func (m Model) HorizontalScrolling() bool {
+	return m.scrolls()
+}
+
+// scrolls returns whether the user can scroll the columns horizontally.
+func (m Model) scrolls() bool {
+	return m.horizontalScrolling || m.frozenColumns() > 0
+}
+
+// frozenColumns returns the number of frozen columns, at most the number of
+// columns.
+func (m Model) frozenColumns() int {
+	return min(m.frozen, len(m.cols))
+}
+
+// frozenWidth returns the width taken up by the frozen columns.
+func (m Model) frozenWidth() int {
+	frame := m.styles.Cell.GetHorizontalFrameSize()
+	w := 0
+	for _, col := range m.cols[:m.frozenColumns()] {
+		if col.Width > 0 {
+			w += col.Width + frame
+		}
+	}
+	return w
+}
+
+// ScrollLeft scrolls the columns that aren't frozen left by the given number
+// of columns.
+func (m *Model) ScrollLeft(n int) {
+	starts, _ := m.columnStarts()
+	for range n {
+		offset := 0
+		for _, start := range starts {
+			if start < m.viewport.XOffset() {
+				offset = start
+			}
+		}
+		m.viewport.SetXOffset(offset)
+	}
+}
+
+// ScrollRight scrolls the columns that aren't frozen right by the given number
+// of columns. It stops once the last column is in view.
+func (m *Model) ScrollRight(n int) {
+	starts, width := m.columnStarts()
+	for range n {
+		if m.viewport.XOffset()+m.scrollWidth() >= width {
+			break
+		}
+		for _, start := range starts {
+			if start > m.viewport.XOffset() {
+				m.viewport.SetXOffset(start)
+				break
+			}
+		}
+	}
+}
+
+// scrollWidth returns the width in which the columns that aren't frozen
+// scroll. With horizontal scrolling, that's between the frozen columns and the
+// cells of the scroll indicators on either side.
+func (m Model) scrollWidth() int {
+	if !m.scrolls() {
+		return m.viewport.Width()
+	}
+	return m.viewport.Width() - m.frozenWidth() - 2 //nolint:mnd
+}
+
+// columnStarts returns where each visible column that isn't frozen starts,
+// relative to the first of them, and the width of those columns.
+func (m Model) columnStarts() ([]int, int) {
+	frame := m.styles.Cell.GetHorizontalFrameSize()
+	var starts []int
+	x := 0
+	for _, col := range m.cols[m.frozenColumns():] {
+		if col.Width <= 0 {
+			continue
+		}
+		starts = append(starts, x)
+		x += col.Width + frame
+	}
+	return starts, x
+}
+
+// handleWheel scrolls horizontally with the mouse wheel. With the cell cursor,
+// it moves the cursor instead.
+func (m *Model) handleWheel(msg tea.MouseWheelMsg) {
+	// Some terminal emulators send shift+wheel for horizontal scrolling.
+	left := msg.Button == tea.MouseWheelLeft ||
+		msg.Button == tea.MouseWheelUp && msg.Mod.Contains(tea.ModShift)
+	right := msg.Button == tea.MouseWheelRight ||
+		msg.Button == tea.MouseWheelDown && msg.Mod.Contains(tea.ModShift)
+
+	switch {
+	case left && m.cellSelection:
+		m.MoveLeft(1)
+	case right && m.cellSelection:
+		m.MoveRight(1)
+	case left:
+		m.ScrollLeft(1)
+	case right:
+		m.ScrollRight(1)
+	}
+}
+
+// renderScrollingRow splits the given row into the cells that scroll and the
+// frozen cells, which are rendered in the viewport's gutter followed by the
+// cell of the left scroll indicator.
+func (m *Model) renderScrollingRow(r int, row string) (string, string) {
+	frozen := m.frozenWidth()
+	cells := m.renderCells(r, 0, m.frozenColumns())
+	height := max(lipgloss.Height(row), lipgloss.Height(cells))
+
+	// The viewport limits the horizontal offset by its whole width, so pad
+	// the row by that width to let any column scroll to the left edge.
+	row = lipgloss.NewStyle().Height(height).PaddingRight(m.viewport.Width()).Render(row)
+	cells = lipgloss.NewStyle().Width(frozen + 1).Height(height).Render(cells)
+	return row, cells
+}
+
+// setFrozenGutter renders the given lines of the frozen columns to the left
+// of the viewport, where they don't scroll.
+func (m *Model) setFrozenGutter(lines []string) {
+	if !m.scrolls() {
+		m.viewport.LeftGutterFunc = viewport.NoGutter
+		return
+	}
+	blank := strings.Repeat(" ", m.frozenWidth()+1)
+	m.viewport.LeftGutterFunc = func(info viewport.GutterContext) string {
+		if info.Index < len(lines) {
+			return lines[info.Index]
+		}
+		return blank
+	}
+}
+
+// scrollingView renders the table with horizontal scrolling. The frozen
+// columns stay in place, and the headers of the others scroll along with the
+// rows, between cells showing indicators of columns to either side.
+func (m Model) scrollingView() string {
+	width := max(0, m.scrollWidth())
+	offset := m.viewport.XOffset()
+	headers := m.headerCells(m.frozenColumns(), len(m.cols))
+	more := lipgloss.Width(headers) > offset+width
+
+	// Show the indicators on the line of the titles.
+	h := m.styles.Header
+	title := h.GetMarginTop() + h.GetBorderTopSize() + h.GetPaddingTop()
+	lines := strings.Split(headers, "\n")
+	left := make([]string, len(lines))
+	right := make([]string, len(lines))
+	for i, line := range lines {
+		lines[i] = ansi.Cut(line, offset, offset+width)
+		left[i], right[i] = " ", " "
+	}
+	if title < len(lines) {
+		if offset > 0 {
+			left[title] = m.styles.ScrollIndicator.Render(scrollLeftIndicator)
+		}
+		if more {
+			right[title] = m.styles.ScrollIndicator.Render(scrollRightIndicator)
+		}
+	}
+	headers = lipgloss.JoinHorizontal(lipgloss.Top,
+		strings.Join(left, "\n"),
+		lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n")),
+		strings.Join(right, "\n"),
+	)
+	if n := m.frozenColumns(); n > 0 {
+		frozen := lipgloss.NewStyle().Width(m.frozenWidth()).Render(m.headerCells(0, n))
+		headers = lipgloss.JoinHorizontal(lipgloss.Top, frozen, headers)
+	}
+
+	// Leave the cell of the right indicator blank in the rows.
+	rows := strings.Split(m.viewport.View(), "\n")
+	for i, row := range rows {
+		rows[i] = ansi.Truncate(row, m.viewport.Width()-1, "")
+	}
+	return headers + "\n" + lipgloss.NewStyle().Width(m.viewport.Width()).Render(strings.Join(rows, "\n"))
+}
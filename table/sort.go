@@ -0,0 +1,290 @@
+package table
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SortDirection is the direction the rows of a table are sorted in.
+type SortDirection int
+
+// Sort directions.
+const (
+	Unsorted SortDirection = iota
+	SortAscending
+	SortDescending
+)
+
+const (
+	sortAscendingMark  = "▲"
+	sortDescendingMark = "▼"
+)
+
+// CompareFunc compares two cell values when sorting by a column. It returns a
+// negative number when a sorts before b, a positive number when a sorts after
+// b, and zero when they're equal.
+type CompareFunc func(a, b string) int
+
+// CompareStrings compares values as strings. This is the default.
+func CompareStrings(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// CompareNumbers compares values as numbers. Values that aren't numbers sort
+// after those that are.
+func CompareNumbers(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(x, y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// CompareNatural compares values as strings, except that runs of digits are
+// compared by their numeric value, so that "file2" sorts before "file10".
+// Letters are compared case-insensitively.
+func CompareNatural(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	for len(x) > 0 && len(y) > 0 {
+		if unicode.IsDigit(x[0]) && unicode.IsDigit(y[0]) {
+			var numX, numY []rune
+			numX, x = splitDigits(x)
+			numY, y = splitDigits(y)
+			if c := compareDigits(numX, numY); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := cmp.Compare(unicode.ToLower(x[0]), unicode.ToLower(y[0])); c != 0 {
+			return c
+		}
+		x, y = x[1:], y[1:]
+	}
+	if c := cmp.Compare(len(x), len(y)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// splitDigits splits the leading run of digits off s.
+func splitDigits(s []rune) (digits, rest []rune) {
+	i := 0
+	for i < len(s) && unicode.IsDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareDigits compares two runs of digits by their numeric value.
+func compareDigits(a, b []rune) int {
+	a = trimZeros(a)
+	b = trimZeros(b)
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return slices.Compare(a, b)
+}
+
+func trimZeros(s []rune) []rune {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+// CompareDates returns a CompareFunc that compares values as dates in the
+// given layout, as used by time.Parse. Values that aren't dates sort after
+// those that are.
+func CompareDates(layout string) CompareFunc {
+	return func(a, b string) int {
+		x, errA := time.Parse(layout, strings.TrimSpace(a))
+		y, errB := time.Parse(layout, strings.TrimSpace(b))
+		switch {
+		case errA == nil && errB == nil:
+			return x.Compare(y)
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	}
+}
+
+// WithSortable lets the user sort the rows, see SetSortable.
+func WithSortable(v bool) Option {
+	return func(m *Model) {
+		m.sortable = v
+	}
+}
+
+// SetSortable sets whether the user can sort the rows, with the keybindings
+// KeyMap.Sort, KeyMap.PrevSortColumn and KeyMap.NextSortColumn, and by
+// clicking the headers. By default, they can't. SetSort sorts the rows
+// either way.
+func (m *Model) SetSortable(v bool) {
+	m.sortable = v
+	m.updateKeybindings()
+}
+
+// Sortable returns whether the user can sort the rows.
+func (m Model) Sortable() bool {
+	return m.sortable
+}
+
+// SetSort sorts the rows by the given column in the given direction. Sorting
+// in the Unsorted direction, or by a column out of range, restores the rows
+// to the order they were set in. The cursor stays on the selected row.
+func (m *Model) SetSort(column int, direction SortDirection) {
+	if column < 0 || column >= len(m.cols) || direction == Unsorted {
+		column, direction = 0, Unsorted
+	}
+	m.sortColumn, m.sortDirection = column, direction
+	m.resort()
+}
+
+// SortOrder returns the column the rows are sorted by, and the direction.
+func (m Model) SortOrder() (column int, direction SortDirection) {
+	return m.sortColumn, m.sortDirection
+}
+
+// CycleSort sorts by the given column in ascending order, or if the rows are
+// already sorted by it, in descending order, and then not at all.
+func (m *Model) CycleSort(column int) {
+	direction := SortAscending
+	if column == m.sortColumn {
+		switch m.sortDirection {
+		case SortAscending:
+			direction = SortDescending
+		case SortDescending:
+			direction = Unsorted
+		}
+	}
+	m.SetSort(column, direction)
+}
+
+// shiftSort sorts by the column delta columns from the current sort column,
+// keeping the sort direction.
+func (m *Model) shiftSort(delta int) {
+	if len(m.cols) == 0 {
+		return
+	}
+	direction := m.sortDirection
+	if direction == Unsorted {
+		direction = SortAscending
+	}
+	m.SetSort((m.sortColumn+delta+len(m.cols))%len(m.cols), direction)
+}
+
+// resort sorts the rows in the current sort order, keeping the cursor on the
+// selected row.
+func (m *Model) resort() {
+	rows := m.rows
+	selected := m.cursor
+	if m.order != nil {
+		rows = m.unsorted
+		if m.cursor >= 0 && m.cursor < len(m.order) {
+			selected = m.order[m.cursor]
+		}
+	}
+
+	if m.sortDirection == Unsorted {
+		m.rows, m.unsorted, m.order = rows, nil, nil
+		m.cursor = clamp(selected, 0, len(m.rows)-1)
+		m.UpdateViewport()
+		return
+	}
+
+	m.unsorted = rows
+	m.sortRows()
+	if i := slices.Index(m.order, selected); i >= 0 {
+		m.cursor = i
+	}
+	m.UpdateViewport()
+}
+
+// sortRows sorts the unsorted rows into rows, recording the order.
+func (m *Model) sortRows() {
+	compare := m.cols[m.sortColumn].Compare
+	if compare == nil {
+		compare = CompareStrings
+	}
+	value := func(row Row) string {
+		if m.sortColumn < len(row) {
+			return row[m.sortColumn]
+		}
+		return ""
+	}
+
+	m.order = make([]int, len(m.unsorted))
+	for i := range m.order {
+		m.order[i] = i
+	}
+	slices.SortStableFunc(m.order, func(a, b int) int {
+		c := compare(value(m.unsorted[a]), value(m.unsorted[b]))
+		if m.sortDirection == SortDescending {
+			return -c
+		}
+		return c
+	})
+
+	m.rows = make([]Row, len(m.order))
+	for i, j := range m.order {
+		m.rows[i] = m.unsorted[j]
+	}
+}
+
+// sortMark returns the indicator shown in the header of the column the rows
+// are sorted by.
+func (m Model) sortMark(column int) string {
+	if column != m.sortColumn {
+		return ""
+	}
+	switch m.sortDirection {
+	case SortAscending:
+		return sortAscendingMark
+	case SortDescending:
+		return sortDescendingMark
+	default:
+		return ""
+	}
+}
+
+// columnAt returns the index of the column rendered at the given x
+// coordinate.
+func (m Model) columnAt(x int) (int, bool) {
+	switch frozen := m.frozenWidth(); {
+	case !m.scrolls():
+		x += m.viewport.XOffset()
+	case x == frozen:
+		// The cell of the left scroll indicator.
+		return 0, false
+	case x > frozen:
+		// Columns that aren't frozen may be scrolled.
+		x += m.viewport.XOffset() - 1
+	}
+	frame := m.styles.Header.GetHorizontalFrameSize()
+	for i, col := range m.cols {
+		if col.Width <= 0 {
+			continue
+		}
+		w := col.Width + frame
+		if x >= 0 && x < w {
+			return i, true
+		}
+		x -= w
+	}
+	return 0, false
+}
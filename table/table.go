@@ -2,10 +2,12 @@
 package table
 
 import (
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -26,6 +28,30 @@ type Model struct {
 	viewport viewport.Model
 	start    int
 	end      int
+
+	// The column and direction the rows are sorted in. While sorted, rows
+	// holds the sorted rows, unsorted the rows in the order they were set,
+	// and order the index in unsorted of each row.
+	sortColumn    int
+	sortDirection SortDirection
+	sortable      bool
+	unsorted      []Row
+	order         []int
+
+	// Whether the cell cursor is enabled, and the column it's on.
+	cellSelection bool
+	colCursor     int
+
+	// The editor of the focused cell, while editing. committing is set
+	// while the edited value is validated asynchronously before committing.
+	editing    bool
+	committing bool
+	editor     textinput.Model
+
+	// The number of leading columns that don't scroll horizontally, and
+	// whether the user can scroll the columns horizontally.
+	frozen              int
+	horizontalScrolling bool
 }
 
 // Row represents one line in the table.
@@ -34,7 +60,39 @@ type Row []string
 // Column defines the table structure.
 type Column struct {
 	Title string
+
+	// Width is the width of the column. Columns with a width of zero are
+	// hidden. For flexible and auto-sized columns, the table sets Width.
 	Width int
+
+	// Flex, if positive, makes the column share the width of the table left
+	// over by other columns with other flexible columns, in proportion to
+	// their Flex.
+	Flex int
+
+	// Auto sizes the column to fit its title and values.
+	Auto bool
+
+	// MinWidth and MaxWidth bound the width of flexible and auto-sized
+	// columns. A MaxWidth of zero means there's no limit. They're never
+	// narrower than 1, even when there's no space left, so they're never
+	// hidden.
+	MinWidth int
+	MaxWidth int
+
+	// Wrap wraps values that are too wide for the column onto more lines,
+	// making the row taller, instead of truncating them.
+	Wrap bool
+
+	// Compare orders the column's values when sorting by it. By default,
+	// values are compared as strings.
+	Compare CompareFunc
+
+	// Editable allows the user to edit the column's cells. Validate and
+	// AsyncValidate, if set, reject invalid values.
+	Editable      bool
+	Validate      textinput.ValidateFunc
+	AsyncValidate textinput.AsyncValidateFunc
 }
 
 // KeyMap defines keybindings. It satisfies to the help.KeyMap interface, which
@@ -48,6 +106,36 @@ type KeyMap struct {
 	HalfPageDown key.Binding
 	GotoTop      key.Binding
 	GotoBottom   key.Binding
+
+	// Keybindings used to move the cell cursor. They're only enabled with
+	// the cell cursor, see SetCellSelection. NextCell and PrevCell continue
+	// on the next and previous rows.
+	CellLeft  key.Binding
+	CellRight key.Binding
+	NextCell  key.Binding
+	PrevCell  key.Binding
+
+	// Keybindings used to scroll the columns horizontally. They're only
+	// enabled with horizontal scrolling or frozen columns, see
+	// SetHorizontalScrolling and SetFrozenColumns, and without the cell
+	// cursor, which scrolls the table itself.
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+
+	// Keybindings used to edit the focused cell. Edit is only enabled with
+	// the cell cursor and editable columns, and CommitEdit and CancelEdit
+	// while editing.
+	Edit       key.Binding
+	CommitEdit key.Binding
+	CancelEdit key.Binding
+
+	// Sort cycles sorting by the sort column in ascending order, descending
+	// order, and not at all. PrevSortColumn and NextSortColumn sort by the
+	// previous and next columns. They're only enabled when the table is
+	// sortable, see SetSortable.
+	Sort           key.Binding
+	PrevSortColumn key.Binding
+	NextSortColumn key.Binding
 }
 
 // ShortHelp implements the KeyMap interface.
@@ -60,6 +148,10 @@ func (km KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{km.LineUp, km.LineDown, km.GotoTop, km.GotoBottom},
 		{km.PageUp, km.PageDown, km.HalfPageUp, km.HalfPageDown},
+		{km.CellLeft, km.CellRight, km.NextCell, km.PrevCell},
+		{km.ScrollLeft, km.ScrollRight},
+		{km.Edit, km.CommitEdit, km.CancelEdit},
+		{km.Sort, km.PrevSortColumn, km.NextSortColumn},
 	}
 }
 
@@ -98,6 +190,66 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("end", "G"),
 			key.WithHelp("G/end", "go to end"),
 		),
+		CellLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "left"),
+			key.WithDisabled(),
+		),
+		CellRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "right"),
+			key.WithDisabled(),
+		),
+		NextCell: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next cell"),
+			key.WithDisabled(),
+		),
+		PrevCell: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous cell"),
+			key.WithDisabled(),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "scroll left"),
+			key.WithDisabled(),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "scroll right"),
+			key.WithDisabled(),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("enter", "e"),
+			key.WithHelp("enter/e", "edit"),
+			key.WithDisabled(),
+		),
+		CommitEdit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save"),
+			key.WithDisabled(),
+		),
+		CancelEdit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+			key.WithDisabled(),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+			key.WithDisabled(),
+		),
+		PrevSortColumn: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "sort by previous column"),
+			key.WithDisabled(),
+		),
+		NextSortColumn: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "sort by next column"),
+			key.WithDisabled(),
+		),
 	}
 }
 
@@ -107,20 +259,36 @@ type Styles struct {
 	Header   lipgloss.Style
 	Cell     lipgloss.Style
 	Selected lipgloss.Style
+
+	// SelectedCell is used for the focused cell when the cell cursor is
+	// enabled.
+	SelectedCell lipgloss.Style
+
+	// InvalidCell is used for the cell being edited when its value is
+	// invalid.
+	InvalidCell lipgloss.Style
+
+	// ScrollIndicator is used for the indicators shown in the headers when
+	// there are more columns to either side.
+	ScrollIndicator lipgloss.Style
 }
 
 // DefaultStyles returns a set of default style definitions for this table.
 func DefaultStyles() Styles {
 	return Styles{
-		Selected: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
-		Header:   lipgloss.NewStyle().Bold(true).Padding(0, 1),
-		Cell:     lipgloss.NewStyle().Padding(0, 1),
+		Selected:        lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
+		SelectedCell:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62")),
+		InvalidCell:     lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		ScrollIndicator: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("240")),
+		Header:          lipgloss.NewStyle().Bold(true).Padding(0, 1),
+		Cell:            lipgloss.NewStyle().Padding(0, 1),
 	}
 }
 
 // SetStyles sets the table styles.
 func (m *Model) SetStyles(s Styles) {
 	m.styles = s
+	m.layoutColumns()
 	m.UpdateViewport()
 }
 
@@ -144,6 +312,12 @@ func New(opts ...Option) Model {
 		opt(&m)
 	}
 
+	// The default keybindings for optional features are disabled.
+	if m.sortable || m.scrolls() || m.cellSelection {
+		m.updateKeybindings()
+	}
+	m.layoutColumns()
+	m.setColumnCursor(m.colCursor)
 	m.UpdateViewport()
 
 	return m
@@ -204,6 +378,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.editing {
+		cmd := m.handleEditing(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
 		switch {
@@ -223,10 +403,49 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.GotoTop()
 		case key.Matches(msg, m.KeyMap.GotoBottom):
 			m.GotoBottom()
+		case m.cellSelection && key.Matches(msg, m.KeyMap.CellLeft):
+			m.MoveLeft(1)
+		case m.cellSelection && key.Matches(msg, m.KeyMap.CellRight):
+			m.MoveRight(1)
+		case m.cellSelection && key.Matches(msg, m.KeyMap.NextCell):
+			m.nextCell()
+		case m.cellSelection && key.Matches(msg, m.KeyMap.PrevCell):
+			m.prevCell()
+		case !m.cellSelection && m.scrolls() && key.Matches(msg, m.KeyMap.ScrollLeft):
+			m.ScrollLeft(1)
+		case !m.cellSelection && m.scrolls() && key.Matches(msg, m.KeyMap.ScrollRight):
+			m.ScrollRight(1)
+		case m.cellSelection && key.Matches(msg, m.KeyMap.Edit):
+			cmd = m.Edit()
+		case m.sortable && key.Matches(msg, m.KeyMap.Sort):
+			if m.cellSelection {
+				// Sort by the focused column.
+				m.CycleSort(m.colCursor)
+				break
+			}
+			m.CycleSort(m.sortColumn)
+		case m.sortable && key.Matches(msg, m.KeyMap.PrevSortColumn):
+			m.shiftSort(-1)
+		case m.sortable && key.Matches(msg, m.KeyMap.NextSortColumn):
+			m.shiftSort(1)
+		}
+
+	case tea.MouseWheelMsg:
+		if m.scrolls() {
+			m.handleWheel(msg)
+		}
+
+	case tea.MouseClickMsg:
+		// Clicking a column's header sorts by it. Coordinates are relative
+		// to the table.
+		if m.sortable && msg.Button == tea.MouseLeft && msg.Y >= 0 && msg.Y < lipgloss.Height(m.headersView()) {
+			if column, ok := m.columnAt(msg.X); ok {
+				m.CycleSort(column)
+			}
 		}
 	}
 
-	return m, nil
+	return m, cmd
 }
 
 // Focused returns the focus state of the table.
@@ -241,6 +460,28 @@ func (m *Model) Focus() {
 	m.UpdateViewport()
 }
 
+// updateKeybindings enables the keybindings for the features that are turned
+// on.
+func (m *Model) updateKeybindings() {
+	m.KeyMap.Sort.SetEnabled(m.sortable)
+	m.KeyMap.PrevSortColumn.SetEnabled(m.sortable)
+	m.KeyMap.NextSortColumn.SetEnabled(m.sortable)
+	m.KeyMap.CellLeft.SetEnabled(m.cellSelection)
+	m.KeyMap.CellRight.SetEnabled(m.cellSelection)
+	m.KeyMap.NextCell.SetEnabled(m.cellSelection)
+	m.KeyMap.PrevCell.SetEnabled(m.cellSelection)
+
+	m.KeyMap.Edit.SetEnabled(m.cellSelection && !m.editing &&
+		slices.ContainsFunc(m.cols, func(c Column) bool { return c.Editable }))
+	m.KeyMap.CommitEdit.SetEnabled(m.editing)
+	m.KeyMap.CancelEdit.SetEnabled(m.editing)
+
+	// The cell cursor scrolls the table itself.
+	scrolls := m.scrolls() && !m.cellSelection
+	m.KeyMap.ScrollLeft.SetEnabled(scrolls)
+	m.KeyMap.ScrollRight.SetEnabled(scrolls)
+}
+
 // Blur blurs the table, preventing selection or movement.
 func (m *Model) Blur() {
 	m.focus = false
@@ -249,7 +490,15 @@ func (m *Model) Blur() {
 
 // View renders the component.
 func (m Model) View() string {
-	return m.headersView() + "\n" + m.viewport.View()
+	if m.scrolls() {
+		return m.scrollingView()
+	}
+	headers := m.headersView()
+	if offset := m.viewport.XOffset(); offset > 0 {
+		// Scroll the headers along with the rows.
+		headers = ansi.Cut(headers, offset, offset+m.viewport.Width())
+	}
+	return headers + "\n" + m.viewport.View()
 }
 
 // HelpView is a helper method for rendering the help menu from the keymap.
@@ -273,13 +522,25 @@ func (m *Model) UpdateViewport() {
 		m.start = 0
 	}
 	m.end = clamp(m.cursor+m.viewport.Height(), m.cursor, len(m.rows))
+	var frozen []string
 	for i := m.start; i < m.end; i++ {
-		renderedRows = append(renderedRows, m.renderRow(i))
+		row := m.renderRow(i)
+		if m.scrolls() {
+			var cells string
+			row, cells = m.renderScrollingRow(i, row)
+			frozen = append(frozen, strings.Split(cells, "\n")...)
+		}
+		renderedRows = append(renderedRows, row)
 	}
+	m.setFrozenGutter(frozen)
 
 	m.viewport.SetContent(
 		lipgloss.JoinVertical(lipgloss.Left, renderedRows...),
 	)
+	if m.wraps() {
+		m.scrollToCursor(renderedRows)
+	}
+	m.scrollToColumn()
 }
 
 // SelectedRow returns the selected row.
@@ -292,7 +553,7 @@ func (m Model) SelectedRow() Row {
 	return m.rows[m.cursor]
 }
 
-// Rows returns the current rows.
+// Rows returns the current rows, in the order they're displayed.
 func (m Model) Rows() []Row {
 	return m.rows
 }
@@ -302,9 +563,24 @@ func (m Model) Columns() []Column {
 	return m.cols
 }
 
-// SetRows sets a new rows state.
+// SetRows sets a new rows state. If the rows are sorted, they're sorted
+// again, and the cursor stays on the row at the same index in the given rows.
 func (m *Model) SetRows(r []Row) {
 	m.rows = r
+	if m.sortDirection != Unsorted {
+		selected := m.cursor
+		if m.cursor >= 0 && m.cursor < len(m.order) {
+			selected = m.order[m.cursor]
+		}
+		m.unsorted = r
+		m.sortRows()
+		if i := slices.Index(m.order, selected); i >= 0 {
+			m.cursor = i
+		}
+	}
+	if m.fitsContent() {
+		m.layoutColumns()
+	}
 
 	if m.cursor > len(m.rows)-1 {
 		m.cursor = len(m.rows) - 1
@@ -316,12 +592,20 @@ func (m *Model) SetRows(r []Row) {
 // SetColumns sets a new columns state.
 func (m *Model) SetColumns(c []Column) {
 	m.cols = c
+	m.layoutColumns()
+	m.setColumnCursor(m.colCursor)
+	m.updateKeybindings()
+	if m.sortColumn >= len(m.cols) {
+		m.SetSort(0, Unsorted)
+	}
 	m.UpdateViewport()
 }
 
-// SetWidth sets the width of the viewport of the table.
+// SetWidth sets the width of the viewport of the table, resizing flexible
+// columns.
 func (m *Model) SetWidth(w int) {
 	m.viewport.SetWidth(w)
+	m.layoutColumns()
 	m.UpdateViewport()
 }
 
@@ -375,6 +659,10 @@ func (m *Model) MoveUp(n int) {
 func (m *Model) MoveDown(n int) {
 	m.cursor = clamp(m.cursor+n, 0, len(m.rows)-1)
 	m.UpdateViewport()
+	if m.wraps() {
+		// UpdateViewport already scrolled to the cursor.
+		return
+	}
 
 	offset := m.viewport.YOffset()
 	switch {
@@ -416,32 +704,66 @@ func (m *Model) FromValues(value, separator string) {
 }
 
 func (m Model) headersView() string {
-	s := make([]string, 0, len(m.cols))
-	for _, col := range m.cols {
+	return m.headerCells(0, len(m.cols))
+}
+
+// headerCells renders the headers of the columns from the given column up to,
+// but not including, the given end.
+func (m Model) headerCells(from, to int) string {
+	s := make([]string, 0, to-from)
+	for i := from; i < to; i++ {
+		col := m.cols[i]
 		if col.Width <= 0 {
 			continue
 		}
+		title := col.Title
+		if mark := m.sortMark(i); mark != "" {
+			mark = " " + mark
+			title = ansi.Truncate(title, max(0, col.Width-ansi.StringWidth(mark)), "…") + mark
+		}
 		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
-		renderedCell := style.Render(ansi.Truncate(col.Title, col.Width, "…"))
+		renderedCell := style.Render(ansi.Truncate(title, col.Width, "…"))
 		s = append(s, m.styles.Header.Render(renderedCell))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, s...)
 }
 
+// renderRow renders the given row, leaving out the frozen columns.
 func (m *Model) renderRow(r int) string {
-	s := make([]string, 0, len(m.cols))
-	for i, value := range m.rows[r] {
+	return m.renderCells(r, m.frozenColumns(), len(m.cols))
+}
+
+// renderCells renders the cells of the given row from the given column up to,
+// but not including, the given end.
+func (m *Model) renderCells(r, from, to int) string {
+	s := make([]string, 0, to-from)
+	for i := from; i < min(to, len(m.rows[r])); i++ {
+		value := m.rows[r][i]
 		if m.cols[i].Width <= 0 {
 			continue
 		}
-		style := lipgloss.NewStyle().Width(m.cols[i].Width).MaxWidth(m.cols[i].Width).Inline(true)
-		renderedCell := m.styles.Cell.Render(style.Render(ansi.Truncate(value, m.cols[i].Width, "…")))
+		style := lipgloss.NewStyle().Width(m.cols[i].Width).MaxWidth(m.cols[i].Width)
+		if !m.cols[i].Wrap {
+			style = style.Inline(true)
+			value = ansi.Truncate(value, m.cols[i].Width, "…")
+		}
+		renderedCell := m.styles.Cell.Render(style.Render(value))
+		if m.editing && r == m.cursor && i == m.colCursor {
+			renderedCell = m.styles.Cell.Render(style.Render(m.editorView()))
+		} else if m.cellSelection && r == m.cursor {
+			// Style cells individually so the focused one stands out.
+			if i == m.colCursor {
+				renderedCell = m.styles.SelectedCell.Render(renderedCell)
+			} else {
+				renderedCell = m.styles.Selected.Render(renderedCell)
+			}
+		}
 		s = append(s, renderedCell)
 	}
 
 	row := lipgloss.JoinHorizontal(lipgloss.Top, s...)
 
-	if r == m.cursor {
+	if r == m.cursor && !m.cellSelection {
 		return m.styles.Selected.Render(row)
 	}
 
@@ -1,12 +1,15 @@
 package table
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/exp/golden"
@@ -806,3 +809,522 @@ func TestModel_View_CenteredInABox(t *testing.T) {
 
 	golden.RequireEqual(t, []byte(got))
 }
+
+func TestSort(t *testing.T) {
+	table := New(
+		WithColumns([]Column{
+			{Title: "Name", Width: 10, Compare: CompareNatural},
+			{Title: "Size", Width: 10, Compare: CompareNumbers},
+			{Title: "Date", Width: 10, Compare: CompareDates(time.DateOnly)},
+		}),
+		WithRows([]Row{
+			{"file10", "3", "2024-03-01"},
+			{"file2", "10", "2023-12-31"},
+			{"File1", "2.5", "2024-01-15"},
+		}),
+		WithFocused(true),
+	)
+	table.SetCursor(1) // file2
+
+	names := func() string {
+		var s []string
+		for _, row := range table.Rows() {
+			s = append(s, row[0])
+		}
+		return strings.Join(s, " ")
+	}
+	press := func(r rune) {
+		table, _ = table.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	// Sorting is off by default.
+	press('s')
+	if got := names(); got != "file10 file2 File1" {
+		t.Fatalf("expected the rows to stay unsorted, got %q", got)
+	}
+	table.SetSortable(true)
+
+	press('s')
+	if got := names(); got != "File1 file2 file10" {
+		t.Fatalf("expected a natural ascending order, got %q", got)
+	}
+	if table.SelectedRow()[0] != "file2" {
+		t.Fatalf("expected the cursor to stay on file2, got %v", table.SelectedRow())
+	}
+	if !strings.Contains(ansiStrip(table.View()), "Name ▲") {
+		t.Fatalf("expected a sort indicator, got\n%s", table.View())
+	}
+
+	press('s')
+	if got := names(); got != "file10 file2 File1" {
+		t.Fatalf("expected a descending order, got %q", got)
+	}
+
+	press('>')
+	if got := names(); got != "file2 file10 File1" {
+		t.Fatalf("expected a numeric descending order, got %q", got)
+	}
+
+	// Clicking the date header sorts by date.
+	x := 2*(10+DefaultStyles().Header.GetHorizontalFrameSize()) + 1
+	table, _ = table.Update(tea.MouseClickMsg{X: x, Y: 0, Button: tea.MouseLeft})
+	if got := names(); got != "file2 File1 file10" {
+		t.Fatalf("expected a date ascending order, got %q", got)
+	}
+	if column, direction := table.SortOrder(); column != 2 || direction != SortAscending {
+		t.Fatalf("expected to sort by date, got column %d, direction %d", column, direction)
+	}
+
+	table.CycleSort(2)
+	table.CycleSort(2)
+	if got := names(); got != "file10 file2 File1" {
+		t.Fatalf("expected the original order, got %q", got)
+	}
+	if table.SelectedRow()[0] != "file2" {
+		t.Fatalf("expected the cursor to stay on file2, got %v", table.SelectedRow())
+	}
+
+	// Setting rows sorts them and keeps the cursor on the same row.
+	table.SetSort(0, SortDescending)
+	table.SetRows([]Row{
+		{"file10", "3", "2024-03-01"},
+		{"file2", "10", "2023-12-31"},
+		{"File1", "2.5", "2024-01-15"},
+		{"file3", "1", "2024-02-01"},
+	})
+	if got := names(); got != "file10 file3 file2 File1" {
+		t.Fatalf("expected the new rows to be sorted, got %q", got)
+	}
+	if table.SelectedRow()[0] != "file2" {
+		t.Fatalf("expected the cursor to stay on file2, got %v", table.SelectedRow())
+	}
+}
+
+func TestCellCursor(t *testing.T) {
+	table := New(
+		WithColumns([]Column{
+			{Title: "A", Width: 4},
+			{Title: "B", Width: 4},
+			{Title: "Hidden", Width: 0},
+			{Title: "C", Width: 4},
+		}),
+		WithRows([]Row{
+			{"a1", "b1", "x", "c1"},
+			{"a2", "b2", "x", "c2"},
+		}),
+		WithWidth(12),
+		WithHeight(5),
+		WithFocused(true),
+		WithCellSelection(true),
+	)
+
+	press := func(code rune, mod tea.KeyMod) {
+		table, _ = table.Update(tea.KeyPressMsg{Code: code, Mod: mod})
+	}
+	selected := func() Cell {
+		t.Helper()
+		cell, ok := table.SelectedCell()
+		if !ok {
+			t.Fatal("expected a selected cell")
+		}
+		return cell
+	}
+
+	if !table.KeyMap.CellRight.Enabled() || !table.KeyMap.NextCell.Enabled() {
+		t.Fatal("expected the cell keybindings to be enabled")
+	}
+
+	press(tea.KeyRight, 0)
+	if cell := selected(); cell != (Cell{Row: 0, Column: 1, Value: "b1"}) {
+		t.Fatalf("expected b1 to be selected, got %+v", cell)
+	}
+
+	// Hidden columns are skipped, and the table scrolls to show the
+	// focused column.
+	press(tea.KeyRight, 0)
+	if cell := selected(); cell.Value != "c1" {
+		t.Fatalf("expected c1 to be selected, got %+v", cell)
+	}
+	view := ansiStrip(table.View())
+	if !strings.Contains(view, "c1") || strings.Contains(view, "a1") {
+		t.Fatalf("expected the table to scroll to column C, got\n%s", view)
+	}
+
+	press(tea.KeyTab, 0)
+	if cell := selected(); cell != (Cell{Row: 1, Column: 0, Value: "a2"}) {
+		t.Fatalf("expected tab to wrap to a2, got %+v", cell)
+	}
+	if view := ansiStrip(table.View()); !strings.Contains(view, "a2") {
+		t.Fatalf("expected the table to scroll back to column A, got\n%s", view)
+	}
+
+	press(tea.KeyTab, tea.ModShift)
+	if cell := selected(); cell.Value != "c1" {
+		t.Fatalf("expected shift+tab to wrap to c1, got %+v", cell)
+	}
+
+	// Focusing a hidden column focuses the next visible one.
+	table.SetColumnCursor(2)
+	if cell := selected(); cell.Column != 3 {
+		t.Fatalf("expected column C to be focused, got %+v", cell)
+	}
+
+	table.SetCellSelection(false)
+	if _, ok := table.SelectedCell(); ok {
+		t.Fatal("expected no selected cell without the cell cursor")
+	}
+	if table.KeyMap.CellLeft.Enabled() || table.KeyMap.PrevCell.Enabled() {
+		t.Fatal("expected the cell keybindings to be disabled without the cell cursor")
+	}
+
+	// The cursor doesn't start on a hidden column.
+	table = New(
+		WithColumns([]Column{{Title: "Hidden"}, {Title: "A", Width: 4}}),
+		WithRows([]Row{{"x", "a1"}}),
+		WithCellSelection(true),
+	)
+	if cell := selected(); cell.Value != "a1" {
+		t.Fatalf("expected a1 to be selected, got %+v", cell)
+	}
+
+	// Without columns, the cursor stays on the first column.
+	table.SetColumns(nil)
+	table.SetColumnCursor(3)
+	if n := table.ColumnCursor(); n != 0 {
+		t.Fatalf("expected column cursor 0, got %d", n)
+	}
+}
+
+func TestEditCell(t *testing.T) {
+	errEmpty := errors.New("empty")
+	table := New(
+		WithColumns([]Column{
+			{Title: "Name", Width: 10, Editable: true, Validate: func(s string) error {
+				if s == "" {
+					return errEmpty
+				}
+				return nil
+			}},
+			{Title: "ID", Width: 4},
+		}),
+		WithRows([]Row{{"Bob", "1"}, {"Alice", "2"}}),
+		WithHeight(5),
+		WithFocused(true),
+		WithCellSelection(true),
+	)
+	table.SetSort(0, SortAscending)
+
+	press := func(msg tea.KeyPressMsg) tea.Cmd {
+		var cmd tea.Cmd
+		table, cmd = table.Update(msg)
+		return cmd
+	}
+
+	// Columns that aren't editable can't be edited.
+	press(tea.KeyPressMsg{Code: tea.KeyRight})
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if table.Editing() {
+		t.Fatal("expected the ID column not to be editable")
+	}
+
+	if km := table.KeyMap; !km.Edit.Enabled() || km.CommitEdit.Enabled() || km.CancelEdit.Enabled() {
+		t.Fatal("expected only the Edit keybinding to be enabled")
+	}
+
+	press(tea.KeyPressMsg{Code: tea.KeyLeft})
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !table.Editing() {
+		t.Fatal("expected to be editing")
+	}
+	if km := table.KeyMap; km.Edit.Enabled() || !km.CommitEdit.Enabled() || !km.CancelEdit.Enabled() {
+		t.Fatal("expected the CommitEdit and CancelEdit keybindings to be enabled")
+	}
+
+	// Cancelling leaves the cell unchanged.
+	press(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if table.Editing() || table.SelectedRow()[0] != "Bob" {
+		t.Fatalf("expected the edit to be cancelled, got %q", table.SelectedRow()[0])
+	}
+
+	// Invalid values keep the editor open.
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	for range len("Bob") {
+		press(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	}
+	if cmd := press(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Fatal("expected no command for an invalid value")
+	}
+	if !table.Editing() || !errors.Is(table.EditError(), errEmpty) {
+		t.Fatalf("expected to keep editing with an error, got %v", table.EditError())
+	}
+
+	press(tea.KeyPressMsg{Code: 'A', Text: "A"})
+	cmd := press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if table.Editing() {
+		t.Fatal("expected the edit to be committed")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	want := CellEditedMsg{Row: 0, Column: 0, OldValue: "Bob", NewValue: "A"}
+	if msg := cmd(); msg != want {
+		t.Fatalf("expected %+v, got %+v", want, msg)
+	}
+
+	// The rows are sorted again, and the cursor follows the edited row.
+	if row := table.SelectedRow(); row[0] != "A" || row[1] != "1" {
+		t.Fatalf("expected the edited row to stay selected, got %v", row)
+	}
+	if rows := table.Rows(); rows[0][0] != "A" || rows[1][0] != "Alice" {
+		t.Fatalf("expected the rows to be sorted again, got %v", rows)
+	}
+
+	// The app can reject the change.
+	table.SetCell(want.Row, want.Column, want.OldValue)
+	if rows := table.Rows(); rows[0][0] != "Alice" || rows[1][0] != "Bob" {
+		t.Fatalf("expected the change to be reverted, got %v", rows)
+	}
+}
+
+func TestEditCellAsyncValidation(t *testing.T) {
+	errTaken := errors.New("taken")
+	table := New(
+		WithColumns([]Column{
+			{Title: "Name", Width: 10, Editable: true, AsyncValidate: func(s string) tea.Cmd {
+				return func() tea.Msg {
+					if s == "Alice" {
+						return errTaken
+					}
+					return nil
+				}
+			}},
+		}),
+		WithRows([]Row{{"Bob"}, {"Alice"}}),
+		WithHeight(5),
+		WithFocused(true),
+		WithCellSelection(true),
+	)
+
+	update := func(msg tea.Msg) tea.Cmd {
+		var cmd tea.Cmd
+		table, cmd = table.Update(msg)
+		return cmd
+	}
+	commit := func(value string) tea.Msg {
+		t.Helper()
+		update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		table.editor.SetValue(value)
+		cmd := update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatal("expected a validation command")
+		}
+		if !table.Editing() {
+			t.Fatal("expected to keep editing while validating")
+		}
+		return cmd()
+	}
+
+	// The edit isn't committed if the value is rejected.
+	msg := commit("Alice")
+	if cmd := update(msg); cmd != nil {
+		t.Fatalf("expected no command for an invalid value, got %T", cmd())
+	}
+	if !table.Editing() || !errors.Is(table.EditError(), errTaken) {
+		t.Fatalf("expected to keep editing with an error, got %v", table.EditError())
+	}
+	update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	// The edit is committed once the value passes.
+	msg = commit("Carol")
+	if table.SelectedRow()[0] != "Bob" {
+		t.Fatal("expected the cell not to change before validation passes")
+	}
+	cmd := update(msg)
+	if table.Editing() || cmd == nil {
+		t.Fatal("expected the edit to be committed")
+	}
+	want := CellEditedMsg{Row: 0, Column: 0, OldValue: "Bob", NewValue: "Carol"}
+	if msg := cmd(); msg != want {
+		t.Fatalf("expected %+v, got %+v", want, msg)
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	widths := func(table Model) []int {
+		var w []int
+		for _, col := range table.Columns() {
+			w = append(w, col.Width)
+		}
+		return w
+	}
+
+	cols := []Column{
+		{Title: "ID", Width: 4},
+		{Title: "Name", Auto: true, MaxWidth: 8},
+		{Title: "Notes", Flex: 2},
+		{Title: "Tags", Flex: 1, MinWidth: 6},
+	}
+	table := New(
+		WithColumns(cols),
+		WithRows([]Row{
+			{"1", "Bob", "", ""},
+			{"2", "Alexandria", "", ""},
+		}),
+		WithWidth(40),
+		WithStyles(Styles{}),
+	)
+
+	// Name fits its widest value up to its MaxWidth, and Notes and Tags share
+	// the rest 2:1.
+	if got, want := widths(table), []int{4, 8, 19, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+	if cols[2].Width != 0 {
+		t.Fatal("expected the given columns not to be modified")
+	}
+
+	// Tags keeps its MinWidth, and Notes takes what's left.
+	table.SetWidth(20)
+	if got, want := widths(table), []int{4, 8, 2, 6}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+
+	table.SetRows([]Row{{"1", "Al", "", ""}})
+	if got := widths(table)[1]; got != len("Name") {
+		t.Fatalf("expected Name to fit its title, got width %d", got)
+	}
+
+	// Flexible columns aren't hidden when there's no space left.
+	table.SetWidth(10)
+	if got := widths(table)[2]; got != 1 {
+		t.Fatalf("expected Notes to keep a width of 1, got %d", got)
+	}
+}
+
+func TestWrapColumn(t *testing.T) {
+	table := New(
+		WithColumns([]Column{
+			{Title: "ID", Width: 3},
+			{Title: "Text", Width: 5, Wrap: true},
+		}),
+		WithRows([]Row{
+			{"1", "one two three"},
+			{"2", "four"},
+			{"3", "five six"},
+		}),
+		WithHeight(4),
+		WithWidth(8),
+		WithFocused(true),
+		WithStyles(Styles{}),
+	)
+
+	lines := func() []string {
+		var l []string
+		for _, line := range strings.Split(ansiStrip(table.View()), "\n") {
+			l = append(l, strings.TrimRight(line, " "))
+		}
+		return l
+	}
+
+	want := []string{"ID Text", "1  one", "   two", "   three"}
+	if got := lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	// The table scrolls by lines so the whole selected row is visible.
+	table.MoveDown(2)
+	want = []string{"ID Text", "2  four", "3  five", "   six"}
+	if got := lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	table.MoveUp(2)
+	want = []string{"ID Text", "1  one", "   two", "   three"}
+	if got := lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestFrozenColumns(t *testing.T) {
+	table := New(
+		WithColumns([]Column{
+			{Title: "ID", Width: 3},
+			{Title: "A", Width: 3},
+			{Title: "B", Width: 3},
+			{Title: "C", Width: 3},
+			{Title: "D", Width: 3},
+		}),
+		WithRows([]Row{
+			{"1", "a1", "b1", "c1", "d1"},
+			{"2", "a2", "b2", "c2", "d2"},
+		}),
+		WithWidth(10),
+		WithHeight(3),
+		WithFocused(true),
+		WithStyles(Styles{}),
+	)
+
+	view := func() []string {
+		return strings.Split(ansiStrip(table.View()), "\n")
+	}
+
+	// Without horizontal scrolling, the headers are left as they are, and
+	// the scroll keys do nothing.
+	table, _ = table.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	want := []string{"ID A  B  C  D  ", "1  a1 b1 c", "2  a2 b2 c"}
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	// The frozen ID column stays while the others scroll, and indicators show
+	// there are more columns to either side.
+	table.SetFrozenColumns(1)
+	want = []string{"ID  A  B ›", "1   a1 b1 ", "2   a2 b2 "}
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	table, _ = table.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	want = []string{"ID ‹B  C ›", "1   b1 c1 ", "2   b2 c2 "}
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	table, _ = table.Update(tea.MouseWheelMsg{Button: tea.MouseWheelRight})
+	table.ScrollRight(1)
+	want = []string{"ID ‹D     ", "1   d1    ", "2   d2    "}
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	// Scrolling stops at the last column.
+	table.ScrollRight(1)
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	table, _ = table.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp, Mod: tea.ModShift})
+	table.ScrollLeft(2)
+	want = []string{"ID  A  B ›", "1   a1 b1 ", "2   a2 b2 "}
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	// The cell cursor scrolls to the focused column, beside the frozen one.
+	table.SetCellSelection(true)
+	table.SetColumnCursor(4)
+	if got := view(); got[1] != "1   d1    " {
+		t.Fatalf("expected the table to scroll to column D, got\n%q", got)
+	}
+
+	// Horizontal scrolling works without frozen columns too.
+	table.SetCellSelection(false)
+	table.SetFrozenColumns(0)
+	table.SetHorizontalScrolling(true)
+	table.ScrollLeft(4)
+	want = []string{" ID A  B ›", " 1  a1 b1 ", " 2  a2 b2 "}
+	if got := view(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%q\ngot\n%q", want, got)
+	}
+}
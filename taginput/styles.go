@@ -0,0 +1,55 @@
+package taginput
+
+import (
+	"charm.land/bubbles/v2/textinput"
+	"charm.land/lipgloss/v2"
+)
+
+// DefaultStyles returns the default styles for the tag input.
+func DefaultStyles(isDark bool) Styles {
+	lightDark := lipgloss.LightDark(isDark)
+
+	var s Styles
+	s.Prompt = lipgloss.NewStyle().Foreground(lipgloss.Color("7"))
+	s.Tag = lipgloss.NewStyle().
+		Foreground(lightDark(lipgloss.Color("#1a1a1a"), lipgloss.Color("#dddddd"))).
+		Background(lightDark(lipgloss.Color("#DDDADA"), lipgloss.Color("#3C3C3C"))).
+		Padding(0, 1)
+	s.SelectedTag = s.Tag.
+		Foreground(lipgloss.Color("230")).
+		Background(lipgloss.Color("62"))
+	s.Gap = " "
+	s.Input = textinput.DefaultStyles(isDark)
+	return s
+}
+
+// DefaultLightStyles returns the default styles for a light background.
+func DefaultLightStyles() Styles {
+	return DefaultStyles(false)
+}
+
+// DefaultDarkStyles returns the default styles for a dark background.
+func DefaultDarkStyles() Styles {
+	return DefaultStyles(true)
+}
+
+// Styles are the styles for the tag input.
+//
+// For an introduction to styling with Lip Gloss see:
+// https://github.com/charmbracelet/lipgloss
+type Styles struct {
+	// Prompt styles the prompt rendered before the tags.
+	Prompt lipgloss.Style
+
+	// Tag styles each tag.
+	Tag lipgloss.Style
+
+	// SelectedTag styles the tag selected with the arrow keys.
+	SelectedTag lipgloss.Style
+
+	// Gap is the string rendered between tags.
+	Gap string
+
+	// Input styles the text input used to edit the current tag.
+	Input textinput.Styles
+}
@@ -0,0 +1,353 @@
+// Package taginput provides a tag (or "chip") input component for Bubble Tea
+// applications. Text is entered in a textinput and converted into discrete
+// tags whenever a separator is typed.
+package taginput
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// ErrDuplicate is reported in [Model.Err] when a tag that already exists is
+// entered and duplicates are not allowed.
+var ErrDuplicate = errors.New("duplicate tag")
+
+// ErrLimit is reported in [Model.Err] when a tag is entered while the maximum
+// number of tags has already been reached.
+var ErrLimit = errors.New("tag limit reached")
+
+// ValidateFunc is a function that returns an error if a single tag is
+// invalid.
+type ValidateFunc func(string) error
+
+// KeyMap is the key bindings for different actions within the tag input.
+// Bindings not listed here are handled by the underlying textinput.
+type KeyMap struct {
+	// Accept converts the text currently being edited into a tag.
+	Accept key.Binding
+
+	// TagBackward selects the previous tag. When the cursor is at the start
+	// of the input this selects the last tag.
+	TagBackward key.Binding
+
+	// TagForward selects the next tag. Moving past the last tag returns
+	// focus to the input.
+	TagForward key.Binding
+
+	// DeleteTag removes the selected tag.
+	DeleteTag key.Binding
+
+	// EditLastTag moves the last tag back into the input for editing. It
+	// only applies when no tag is selected and the input is empty.
+	EditLastTag key.Binding
+}
+
+// DefaultKeyMap is the default set of key bindings for navigating and acting
+// upon the tag input.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Accept:      key.NewBinding(key.WithKeys("enter")),
+		TagBackward: key.NewBinding(key.WithKeys("left")),
+		TagForward:  key.NewBinding(key.WithKeys("right")),
+		DeleteTag:   key.NewBinding(key.WithKeys("backspace", "delete")),
+		EditLastTag: key.NewBinding(key.WithKeys("backspace")),
+	}
+}
+
+// Model is the Bubble Tea model for this tag input element.
+type Model struct {
+	// Err is the error returned by the last rejected tag, if any.
+	Err error
+
+	// Prompt is rendered before the tags.
+	Prompt string
+
+	// Input is the textinput used to edit the tag currently being typed.
+	Input textinput.Model
+
+	// Separators are the strings that, when typed or pasted, end the current
+	// tag. By default tags are separated by commas.
+	Separators []string
+
+	// AllowDuplicates determines whether the same tag may be entered more
+	// than once.
+	AllowDuplicates bool
+
+	// Limit is the maximum number of tags. If 0 or less there's no limit.
+	Limit int
+
+	// Validate is called for every tag before it is added. If it returns an
+	// error the tag is rejected, left in the input and the error is stored in
+	// Err.
+	Validate ValidateFunc
+
+	// KeyMap encodes the keybindings recognized by the widget.
+	KeyMap KeyMap
+
+	styles Styles
+
+	tags []string
+
+	// selected is the index of the selected tag, or -1 if the input is being
+	// edited.
+	selected int
+}
+
+// New creates a new model with default settings.
+func New() Model {
+	input := textinput.New()
+	input.Prompt = ""
+
+	m := Model{
+		Prompt:     "> ",
+		Input:      input,
+		Separators: []string{","},
+		KeyMap:     DefaultKeyMap(),
+		selected:   -1,
+	}
+	m.SetStyles(DefaultDarkStyles())
+	return m
+}
+
+// Styles returns the current set of styles.
+func (m Model) Styles() Styles {
+	return m.styles
+}
+
+// SetStyles sets the styles for the tag input.
+func (m *Model) SetStyles(s Styles) {
+	m.styles = s
+	m.Input.SetStyles(s.Input)
+}
+
+// Value returns the tags that have been entered.
+func (m Model) Value() []string {
+	return slices.Clone(m.tags)
+}
+
+// SetValue replaces the tags. Tags are not validated or deduplicated.
+func (m *Model) SetValue(tags []string) {
+	m.tags = slices.Clone(tags)
+	m.selected = -1
+}
+
+// Reset removes all tags and clears the input.
+func (m *Model) Reset() {
+	m.tags = nil
+	m.selected = -1
+	m.Err = nil
+	m.Input.Reset()
+}
+
+// Selected returns the index of the selected tag, or -1 if no tag is
+// selected and the input is being edited.
+func (m Model) Selected() int {
+	return m.selected
+}
+
+// Focused returns the focus state on the model.
+func (m Model) Focused() bool {
+	return m.Input.Focused()
+}
+
+// Focus sets the focus state on the model. When the model is in focus it can
+// receive keyboard input and the cursor will be shown.
+func (m *Model) Focus() tea.Cmd {
+	return m.Input.Focus()
+}
+
+// Blur removes the focus state on the model. Any selected tag is deselected.
+func (m *Model) Blur() {
+	m.selected = -1
+	m.Input.Blur()
+}
+
+// Update is the Bubble Tea update loop.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.Focused() {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		if m.selected >= 0 {
+			return m.updateSelected(msg)
+		}
+
+		switch {
+		case key.Matches(msg, m.KeyMap.Accept):
+			if m.Input.Value() != "" {
+				m.tokenize(true)
+				return m, nil
+			}
+		case key.Matches(msg, m.KeyMap.TagBackward):
+			if m.Input.Position() == 0 && len(m.tags) > 0 {
+				m.selected = len(m.tags) - 1
+				return m, nil
+			}
+		case key.Matches(msg, m.KeyMap.EditLastTag):
+			if m.Input.Value() == "" && len(m.tags) > 0 {
+				m.editLast()
+				return m, nil
+			}
+		}
+	}
+
+	return m.updateInput(msg)
+}
+
+// updateInput passes msg to the input and converts any completed tags.
+func (m Model) updateInput(msg tea.Msg) (Model, tea.Cmd) {
+	oldValue := m.Input.Value()
+
+	var cmd tea.Cmd
+	m.Input, cmd = m.Input.Update(msg)
+	if m.Input.Value() != oldValue {
+		m.Err = nil
+	}
+	m.tokenize(false)
+	return m, cmd
+}
+
+// updateSelected handles key presses while a tag is selected.
+func (m Model) updateSelected(msg tea.KeyPressMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.KeyMap.TagBackward):
+		m.selected = max(0, m.selected-1)
+	case key.Matches(msg, m.KeyMap.TagForward):
+		m.selected++
+		if m.selected >= len(m.tags) {
+			m.selected = -1
+			m.Input.CursorStart()
+		}
+	case key.Matches(msg, m.KeyMap.DeleteTag):
+		m.tags = slices.Delete(m.tags, m.selected, m.selected+1)
+		m.Err = nil
+		if m.selected >= len(m.tags) {
+			m.selected = -1
+		}
+	default:
+		// Any other key returns to the input so typing isn't lost.
+		m.selected = -1
+		return m.updateInput(msg)
+	}
+	return m, nil
+}
+
+// editLast removes the last tag and moves its text into the input.
+func (m *Model) editLast() {
+	last := m.tags[len(m.tags)-1]
+	m.tags = m.tags[:len(m.tags)-1]
+	m.Err = nil
+	m.Input.SetValue(last)
+	m.Input.CursorEnd()
+}
+
+// tokenize converts the complete tags in the input into tags. If final is
+// true the text after the last separator is treated as a complete tag, too.
+func (m *Model) tokenize(final bool) {
+	value := m.Input.Value()
+	tokens, offsets := m.split(value)
+	if !final && len(tokens) == 1 {
+		return
+	}
+
+	rest := value[offsets[len(tokens)-1]:]
+	if !final {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	m.Err = nil
+	for i, tok := range tokens {
+		if err := m.add(tok); err != nil {
+			m.Err = err
+			rest = value[offsets[i]:]
+			break
+		}
+		if final && i == len(tokens)-1 {
+			rest = ""
+		}
+	}
+
+	if rest != value {
+		m.Input.SetValue(rest)
+		m.Input.CursorEnd()
+	}
+}
+
+// add validates a single tag and appends it.
+func (m *Model) add(tag string) error {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil
+	}
+	if m.Validate != nil {
+		if err := m.Validate(tag); err != nil {
+			return err
+		}
+	}
+	if !m.AllowDuplicates && slices.Contains(m.tags, tag) {
+		return ErrDuplicate
+	}
+	if m.Limit > 0 && len(m.tags) >= m.Limit {
+		return ErrLimit
+	}
+	m.tags = append(m.tags, tag)
+	return nil
+}
+
+// split splits s on any of the separators. It returns the tokens along with
+// the byte offset at which each token starts.
+func (m Model) split(s string) (tokens []string, offsets []int) {
+	start := 0
+	for i := 0; i < len(s); {
+		sep := m.separatorAt(s[i:])
+		if sep == "" {
+			i++
+			continue
+		}
+		tokens = append(tokens, s[start:i])
+		offsets = append(offsets, start)
+		i += len(sep)
+		start = i
+	}
+	return append(tokens, s[start:]), append(offsets, start)
+}
+
+// separatorAt returns the separator s begins with, if any.
+func (m Model) separatorAt(s string) string {
+	for _, sep := range m.Separators {
+		if sep != "" && strings.HasPrefix(s, sep) {
+			return sep
+		}
+	}
+	return ""
+}
+
+// View renders the tag input in its current state.
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Prompt.Render(m.Prompt))
+	for i, tag := range m.tags {
+		style := m.styles.Tag
+		if i == m.selected {
+			style = m.styles.SelectedTag
+		}
+		b.WriteString(style.Render(tag))
+		b.WriteString(m.styles.Gap)
+	}
+
+	input := m.Input
+	if len(m.tags) > 0 {
+		input.Placeholder = ""
+	}
+	if m.selected >= 0 {
+		input.Blur()
+	}
+	b.WriteString(input.View())
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package taginput
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestSeparatorCreatesTags(t *testing.T) {
+	m := New()
+	m.Focus()
+	m = sendString(m, "foo,bar, baz")
+
+	if got, want := m.Value(), []string{"foo", "bar"}; !slices.Equal(got, want) {
+		t.Fatalf("expected tags %v, got %v", want, got)
+	}
+	if got := m.Input.Value(); got != " baz" {
+		t.Fatalf("expected input %q, got %q", " baz", got)
+	}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if got, want := m.Value(), []string{"foo", "bar", "baz"}; !slices.Equal(got, want) {
+		t.Fatalf("expected tags %v, got %v", want, got)
+	}
+	if got := m.Input.Value(); got != "" {
+		t.Fatalf("expected empty input, got %q", got)
+	}
+}
+
+func TestPasteMultipleSeparators(t *testing.T) {
+	m := New()
+	m.Separators = []string{",", ";"}
+	m.Focus()
+	m, _ = m.Update(tea.PasteMsg{Content: "a;b,,c;"})
+
+	if got, want := m.Value(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("expected tags %v, got %v", want, got)
+	}
+}
+
+func TestDuplicatesAndValidation(t *testing.T) {
+	m := New()
+	m.Validate = func(s string) error {
+		if !strings.Contains(s, "@") {
+			return errors.New("not an email")
+		}
+		return nil
+	}
+	m.Focus()
+
+	m = sendString(m, "a@b,a@b,")
+	if !errors.Is(m.Err, ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got %v", m.Err)
+	}
+	if got := m.Input.Value(); got != "a@b," {
+		t.Fatalf("expected rejected tag to stay in input, got %q", got)
+	}
+
+	m.Input.Reset()
+	m = sendString(m, "nope,")
+	if m.Err == nil || m.Err.Error() != "not an email" {
+		t.Fatalf("expected validation error, got %v", m.Err)
+	}
+	if got, want := m.Value(), []string{"a@b"}; !slices.Equal(got, want) {
+		t.Fatalf("expected tags %v, got %v", want, got)
+	}
+}
+
+func TestBackspaceEditsLastTag(t *testing.T) {
+	m := New()
+	m.SetValue([]string{"foo", "bar"})
+	m.Focus()
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	if got, want := m.Value(), []string{"foo"}; !slices.Equal(got, want) {
+		t.Fatalf("expected tags %v, got %v", want, got)
+	}
+	if got := m.Input.Value(); got != "bar" {
+		t.Fatalf("expected input %q, got %q", "bar", got)
+	}
+}
+
+func TestNavigateAndDeleteTags(t *testing.T) {
+	m := New()
+	m.SetValue([]string{"foo", "bar", "baz"})
+	m.Focus()
+
+	left := tea.KeyPressMsg{Code: tea.KeyLeft}
+	right := tea.KeyPressMsg{Code: tea.KeyRight}
+
+	m, _ = m.Update(left)
+	m, _ = m.Update(left)
+	if m.Selected() != 1 {
+		t.Fatalf("expected tag 1 to be selected, got %d", m.Selected())
+	}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDelete})
+	if got, want := m.Value(), []string{"foo", "baz"}; !slices.Equal(got, want) {
+		t.Fatalf("expected tags %v, got %v", want, got)
+	}
+
+	m, _ = m.Update(right)
+	if m.Selected() != -1 {
+		t.Fatalf("expected input to be active, got selection %d", m.Selected())
+	}
+}
+
+func sendString(m Model, str string) Model {
+	for _, k := range str {
+		m, _ = m.Update(tea.KeyPressMsg{Code: k, Text: string(k)})
+	}
+	return m
+}
@@ -0,0 +1,206 @@
+package textinput
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Completer completes the value of a text input. It's invoked when a key in
+// [KeyMap.Complete] is pressed. See [PathCompleter] for an implementation.
+//
+// The text input only renders the completed value. Completers that offer a
+// list of candidates, such as [PathCompleter.Candidates], leave it to the
+// caller to render them, for instance below the input.
+type Completer interface {
+	// Complete receives the current value and cursor position and returns
+	// the completed value and new cursor position, both in runes.
+	Complete(value string, pos int) (string, int)
+}
+
+// PathCompleter provides shell-style file system path completion for a text
+// input. The first completion request completes the longest prefix shared by
+// all matching entries. If that doesn't narrow things down, a second request
+// lists the matching entries, which are available via [PathCompleter.Candidates].
+// The text input doesn't render the candidates; it's up to the caller to
+// show them.
+//
+// Note that PathCompleter holds state between requests so it should be used
+// as a pointer:
+//
+//	pc := textinput.NewPathCompleter()
+//	input.Completer = &pc
+type PathCompleter struct {
+	// FS is the file system to complete against. Paths are looked up using
+	// slash-separated names, so absolute paths require a file system rooted
+	// at "/", such as the one returned by os.DirFS("/").
+	FS fs.FS
+
+	// WorkingDir is the directory, within FS, that relative paths are
+	// resolved against.
+	WorkingDir string
+
+	// HomeDir is the directory that a leading "~" expands to. If empty, "~"
+	// is not expanded.
+	HomeDir string
+
+	// ShowHidden determines whether hidden entries, those whose names start
+	// with a dot, are offered. Hidden entries are always offered when the
+	// typed name itself starts with a dot.
+	ShowHidden bool
+
+	// DirsOnly restricts completions to directories.
+	DirsOnly bool
+
+	// candidates holds the entries listed by the last ambiguous completion.
+	candidates []string
+
+	// last is the value produced by the previous completion. It's used to
+	// detect repeated requests.
+	last    string
+	hasLast bool
+}
+
+// NewPathCompleter returns a PathCompleter for the operating system's file
+// system, resolving relative paths against the current working directory.
+func NewPathCompleter() PathCompleter {
+	c := PathCompleter{FS: os.DirFS("/")}
+	if wd, err := os.Getwd(); err == nil {
+		c.WorkingDir = filepath.ToSlash(wd)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		c.HomeDir = filepath.ToSlash(home)
+	}
+	return c
+}
+
+// Candidates returns the entries that matched the last ambiguous completion,
+// if the completion was requested twice in a row. Directories carry a
+// trailing separator.
+func (c PathCompleter) Candidates() []string {
+	return c.candidates
+}
+
+// Complete completes the path before the cursor. It satisfies the [Completer]
+// interface.
+func (c *PathCompleter) Complete(value string, pos int) (string, int) {
+	runes := []rune(value)
+	pos = clamp(pos, 0, len(runes))
+	head, tail := string(runes[:pos]), string(runes[pos:])
+
+	repeated := c.hasLast && value == c.last
+	c.candidates = nil
+	c.hasLast = true
+
+	head = c.expandHome(head)
+	dir, base := splitPath(head)
+
+	names, err := c.matches(dir, base)
+	if err != nil || len(names) == 0 {
+		c.last = head + tail
+		return c.last, len([]rune(head))
+	}
+
+	completed := dir + commonPrefix(names)
+	if completed == head && len(names) > 1 && repeated {
+		c.candidates = names
+	}
+
+	c.last = completed + tail
+	return c.last, len([]rune(completed))
+}
+
+// expandHome replaces a leading "~" with the home directory.
+func (c PathCompleter) expandHome(p string) string {
+	if c.HomeDir == "" {
+		return p
+	}
+	if p == "~" {
+		return strings.TrimSuffix(c.HomeDir, "/") + "/"
+	}
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		return strings.TrimSuffix(c.HomeDir, "/") + "/" + rest
+	}
+	return p
+}
+
+// matches returns the sorted names of entries in dir starting with base.
+// Directories are returned with a trailing separator.
+func (c PathCompleter) matches(dir, base string) ([]string, error) {
+	fsDir := c.fsPath(dir)
+	entries, err := fs.ReadDir(c.FS, fsDir)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	showHidden := c.ShowHidden || strings.HasPrefix(base, ".")
+
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, base) {
+			continue
+		}
+		if !showHidden && strings.HasPrefix(name, ".") {
+			// Entries of an fs.FS have no real path to check for
+			// hidden attributes, so go by the dot-prefix convention.
+			continue
+		}
+
+		isDir := e.IsDir()
+		if !isDir && e.Type()&fs.ModeSymlink != 0 {
+			// Follow symlinks so links to directories complete like
+			// directories.
+			if info, err := fs.Stat(c.FS, path.Join(fsDir, name)); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+
+		switch {
+		case isDir:
+			name += "/"
+		case c.DirsOnly:
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// fsPath converts a directory as typed by the user into a path within FS.
+func (c PathCompleter) fsPath(dir string) string {
+	if !path.IsAbs(dir) {
+		dir = path.Join(c.WorkingDir, dir)
+	}
+	dir = strings.TrimPrefix(path.Clean(dir), "/")
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// splitPath splits p after its last separator.
+func splitPath(p string) (dir, base string) {
+	i := strings.LastIndex(p, "/")
+	return p[:i+1], p[i+1:]
+}
+
+// commonPrefix returns the longest prefix shared by all strings.
+func commonPrefix(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	prefix := s[0]
+	for _, v := range s[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
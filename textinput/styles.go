@@ -19,12 +19,16 @@ func DefaultStyles(isDark bool) Styles {
 		Suggestion:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Prompt:      lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
 		Text:        lipgloss.NewStyle(),
+		Error:       lipgloss.NewStyle().Foreground(lightDark(lipgloss.Color("1"), lipgloss.Color("9"))),
+		Hint:        lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 	}
 	s.Blurred = StyleState{
 		Placeholder: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Suggestion:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Prompt:      lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
 		Text:        lipgloss.NewStyle().Foreground(lightDark(lipgloss.Color("245"), lipgloss.Color("7"))),
+		Error:       lipgloss.NewStyle().Foreground(lightDark(lipgloss.Color("1"), lipgloss.Color("9"))),
+		Hint:        lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 	}
 	s.Cursor = CursorStyle{
 		Color: lipgloss.Color("7"),
@@ -65,6 +69,11 @@ type StyleState struct {
 	Placeholder lipgloss.Style
 	Suggestion  lipgloss.Style
 	Prompt      lipgloss.Style
+
+	// Error and Hint style the line rendered beneath the input. See
+	// [Model.ShowErrors] and [Model.Hint].
+	Error lipgloss.Style
+	Hint  lipgloss.Style
 }
 
 // CursorStyle is the style for real and virtual cursors.
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 	"unicode"
 
 	"charm.land/bubbles/v2/cursor"
@@ -24,6 +26,22 @@ type (
 	pasteErrMsg struct{ error }
 )
 
+// Internal ID management. Used to ensure that peek messages are received only
+// by the text input that sent them.
+var lastID int64
+
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// PeekMsg signals that the most recently typed character should be masked
+// again. It contains metadata that allows us to tell if the message is the
+// one we're expecting.
+type PeekMsg struct {
+	id  int
+	tag int
+}
+
 // EchoMode sets the input behavior of the text input field.
 type EchoMode int
 
@@ -43,6 +61,44 @@ const (
 // ValidateFunc is a function that returns an error if the input is invalid.
 type ValidateFunc func(string) error
 
+// AsyncValidateFunc is a function that validates the input in the
+// background, such as checking whether a username is available. The message
+// produced by the returned command should be an error if the input is invalid
+// and nil otherwise. Any other message is treated as valid.
+type AsyncValidateFunc func(string) tea.Cmd
+
+// ValidationStrategy determines when the input is validated.
+type ValidationStrategy int
+
+const (
+	// ValidateOnChange validates the input every time it changes. This is
+	// the default behavior.
+	ValidateOnChange ValidationStrategy = iota
+
+	// ValidateOnBlur validates the input when it loses focus.
+	ValidateOnBlur
+
+	// ValidateOnSubmit only validates the input when [Model.RunValidation]
+	// is called, typically when a form is submitted.
+	ValidateOnSubmit
+)
+
+// ValidationMsg carries the result of asynchronous validation. It contains
+// metadata that allows us to drop results for values that have since
+// changed.
+type ValidationMsg struct {
+	id  int
+	tag int
+	err error
+}
+
+// validationTickMsg signals that the input has been stable for long enough
+// to start asynchronous validation.
+type validationTickMsg struct {
+	id  int
+	tag int
+}
+
 // KeyMap is the key bindings for different actions within the textinput.
 type KeyMap struct {
 	CharacterForward        key.Binding
@@ -61,6 +117,10 @@ type KeyMap struct {
 	AcceptSuggestion        key.Binding
 	NextSuggestion          key.Binding
 	PrevSuggestion          key.Binding
+	Complete                key.Binding
+	ToggleReveal            key.Binding
+	Undo                    key.Binding
+	Redo                    key.Binding
 }
 
 // DefaultKeyMap is the default set of key bindings for navigating and acting
@@ -83,6 +143,10 @@ func DefaultKeyMap() KeyMap {
 		AcceptSuggestion:        key.NewBinding(key.WithKeys("tab")),
 		NextSuggestion:          key.NewBinding(key.WithKeys("down", "ctrl+n")),
 		PrevSuggestion:          key.NewBinding(key.WithKeys("up", "ctrl+p")),
+		Complete:                key.NewBinding(key.WithKeys("tab")),
+		ToggleReveal:            key.NewBinding(key.WithKeys("ctrl+r")),
+		Undo:                    key.NewBinding(key.WithKeys("ctrl+z")),
+		Redo:                    key.NewBinding(key.WithKeys("ctrl+shift+z", "ctrl+y")),
 	}
 }
 
@@ -96,6 +160,27 @@ type Model struct {
 	EchoMode      EchoMode
 	EchoCharacter rune
 
+	// PeekDuration is how long the most recently typed character is shown
+	// before being masked when EchoMode is EchoPassword, much like password
+	// fields on mobile devices. If 0 or less, characters are masked
+	// immediately.
+	PeekDuration time.Duration
+
+	// The ID of this Model as it relates to other text inputs.
+	id int
+
+	// revealed indicates that a password field is temporarily displayed as
+	// normal text. See [Model.ToggleReveal].
+	revealed bool
+
+	// peeking indicates that the character at peekPos is currently shown
+	// unmasked.
+	peeking bool
+	peekPos int
+
+	// The ID of the peek message we're expecting to receive.
+	peekTag int
+
 	// useVirtualCursor determines whether or not to use the virtual cursor. If
 	// set to false, use [Model.Cursor] to return a real cursor for rendering.
 	useVirtualCursor bool
@@ -140,6 +225,31 @@ type Model struct {
 	// input is considered valid.
 	Validate ValidateFunc
 
+	// AsyncValidate, if set, validates the input in the background after
+	// Validate has passed. Results for values that have since changed are
+	// dropped.
+	AsyncValidate AsyncValidateFunc
+
+	// ValidateOn determines when Validate and AsyncValidate are run.
+	ValidateOn ValidationStrategy
+
+	// ValidationDelay is how long the input must remain unchanged before
+	// asynchronous validation starts when validating on change. This avoids
+	// running expensive checks on every keystroke.
+	ValidationDelay time.Duration
+
+	// ShowErrors renders Err on a line beneath the input.
+	ShowErrors bool
+
+	// Hint is rendered on a line beneath the input when there's no error to
+	// show.
+	Hint string
+
+	// The ID of the validation we're expecting results for, and whether
+	// asynchronous validation is in progress.
+	validationTag int
+	validating    bool
+
 	// rune sanitizer for input.
 	rsan runeutil.Sanitizer
 
@@ -151,11 +261,24 @@ type Model struct {
 	suggestions            [][]rune
 	matchedSuggestions     [][]rune
 	currentSuggestionIndex int
+
+	// Completer, if set, completes the value when a key in KeyMap.Complete
+	// is pressed. See PathCompleter for file system path completion.
+	Completer Completer
+
+	// Undo and redo history.
+	undoStack []snapshot
+	redoStack []snapshot
+
+	// coalesce indicates that the top of the undo stack belongs to a run of
+	// typed characters that further typing should be merged into.
+	coalesce bool
 }
 
 // New creates a new model with default settings.
 func New() Model {
 	m := Model{
+		id:               nextID(),
 		Prompt:           "> ",
 		EchoCharacter:    '*',
 		CharLimit:        0,
@@ -206,8 +329,15 @@ func (m *Model) SetWidth(w int) {
 	m.width = w
 }
 
-// SetValue sets the value of the text input.
+// SetValue sets the value of the text input. This clears the undo history, so
+// a value set from code can't be undone.
 func (m *Model) SetValue(s string) {
+	m.dropValidation()
+	m.setValue(s)
+	m.ClearHistory()
+}
+
+func (m *Model) setValue(s string) {
 	// Clean up any special characters in the input provided by the
 	// caller. This avoids bugs due to e.g. tab characters and whatnot.
 	runes := m.san().Sanitize([]rune(s))
@@ -272,15 +402,49 @@ func (m *Model) Focus() tea.Cmd {
 
 // Blur removes the focus state on the model.  When the model is blurred it can
 // not receive keyboard input and the cursor will be hidden.
+//
+// If ValidateOn is ValidateOnBlur the input is validated with Validate. Since
+// Blur can't return a command, use [Model.RunValidation] instead when
+// asynchronous validation is needed.
 func (m *Model) Blur() {
 	m.focus = false
+	m.peeking = false
 	m.virtualCursor.Blur()
+
+	if m.ValidateOn == ValidateOnBlur {
+		m.dropValidation()
+		m.Err = m.runValidate(m.value)
+	}
+}
+
+// Revealed returns whether a password field is currently displaying its
+// value as normal text.
+func (m Model) Revealed() bool {
+	return m.revealed
+}
+
+// ToggleReveal switches a password field between EchoPassword and
+// EchoNormal so that the user can verify their input. It has no effect on
+// inputs using other echo modes.
+func (m *Model) ToggleReveal() {
+	switch {
+	case m.revealed:
+		m.revealed = false
+		m.EchoMode = EchoPassword
+	case m.EchoMode == EchoPassword:
+		m.revealed = true
+		m.peeking = false
+		m.EchoMode = EchoNormal
+	}
 }
 
 // Reset sets the input to its default state with no input.
 func (m *Model) Reset() {
+	m.peeking = false
+	m.dropValidation()
 	m.value = nil
 	m.SetCursor(0)
+	m.ClearHistory()
 }
 
 // SetSuggestions sets the suggestions for the input.
@@ -576,28 +740,112 @@ func (m Model) echoTransform(v string) string {
 	}
 }
 
+// echoTransformAt is like echoTransform but leaves the peeked character, if
+// any, unmasked. start is the index in the value of the first rune in v.
+func (m Model) echoTransformAt(v []rune, start int) string {
+	i := m.peekPos - start
+	if m.EchoMode != EchoPassword || !m.peeking || i < 0 || i >= len(v) {
+		return m.echoTransform(string(v))
+	}
+	return m.echoTransform(string(v[:i])) + string(v[i]) + m.echoTransform(string(v[i+1:]))
+}
+
+// peek shows the character at the given index unmasked for PeekDuration and
+// returns the command that masks it again.
+func (m *Model) peek(i int) tea.Cmd {
+	if m.EchoMode != EchoPassword || m.PeekDuration <= 0 {
+		return nil
+	}
+	m.peeking = true
+	m.peekPos = i
+	m.peekTag++
+	id, tag := m.id, m.peekTag
+	return tea.Tick(m.PeekDuration, func(time.Time) tea.Msg {
+		return PeekMsg{id: id, tag: tag}
+	})
+}
+
 // Update is the Bubble Tea update loop.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case PeekMsg:
+		// Were we expecting this peek message?
+		if msg.id == m.id && msg.tag == m.peekTag {
+			m.peeking = false
+		}
+		return m, nil
+
+	case validationTickMsg:
+		if msg.id == m.id && msg.tag == m.validationTag {
+			return m, m.validateAsync()
+		}
+		return m, nil
+
+	case ValidationMsg:
+		// Drop results for values that have since changed.
+		if msg.id == m.id && msg.tag == m.validationTag {
+			m.validating = false
+			m.Err = msg.err
+		}
+		return m, nil
+	}
+
 	if !m.focus {
 		return m, nil
 	}
 
+	// Let's remember where the position of the cursor currently is so that if
+	// the cursor position changes, we can reset the blink.
+	oldPos := m.pos
+
+	// Remember the state prior to any edits so they can be undone.
+	var (
+		before    snapshot
+		hasBefore bool
+		restored  bool
+	)
+	switch msg.(type) {
+	case tea.KeyPressMsg, tea.PasteMsg, pasteMsg:
+		before, hasBefore = m.snapshot(), true
+	}
+
 	// Need to check for completion before, because key is configurable and might be double assigned
 	keyMsg, ok := msg.(tea.KeyPressMsg)
-	if ok && key.Matches(keyMsg, m.KeyMap.AcceptSuggestion) {
+	completed := false
+	if ok && m.Completer != nil && key.Matches(keyMsg, m.KeyMap.Complete) {
+		value, pos := m.Completer.Complete(string(m.value), m.pos)
+		m.setValue(value)
+		m.SetCursor(pos)
+		completed = true
+	} else if ok && key.Matches(keyMsg, m.KeyMap.AcceptSuggestion) {
 		if m.canAcceptSuggestion() {
 			m.value = append(m.value, m.matchedSuggestions[m.currentSuggestionIndex][len(m.value):]...)
 			m.CursorEnd()
 		}
 	}
 
-	// Let's remember where the position of the cursor currently is so that if
-	// the cursor position changes, we can reset the blink.
-	oldPos := m.pos
+	var (
+		peekCmd tea.Cmd
+		typed   []rune
+	)
 
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		// Any key press ends the current peek. Typing a character starts a
+		// new one below.
+		m.peeking = false
+
 		switch {
+		case completed:
+			// Already handled above.
+		case key.Matches(msg, m.KeyMap.Undo):
+			m.Undo()
+			restored = true
+		case key.Matches(msg, m.KeyMap.Redo):
+			m.Redo()
+			restored = true
+		case key.Matches(msg, m.KeyMap.ToggleReveal) && (m.EchoMode == EchoPassword || m.revealed):
+			m.ToggleReveal()
 		case key.Matches(msg, m.KeyMap.DeleteWordBackward):
 			m.deleteWordBackward()
 		case key.Matches(msg, m.KeyMap.DeleteCharacterBackward):
@@ -644,7 +892,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.previousSuggestion()
 		default:
 			// Input one or more regular characters.
-			m.insertRunesFromUserInput([]rune(msg.Text))
+			oldLen := len(m.value)
+			typed = []rune(msg.Text)
+			m.insertRunesFromUserInput(typed)
+			if len(m.value) == oldLen+1 {
+				peekCmd = m.peek(m.pos - 1)
+			}
 		}
 
 		// Check again if can be completed
@@ -652,16 +905,26 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.updateSuggestions()
 
 	case tea.PasteMsg:
+		m.peeking = false
 		m.insertRunesFromUserInput([]rune(msg.Content))
 
 	case pasteMsg:
+		m.peeking = false
 		m.insertRunesFromUserInput([]rune(msg))
 
 	case pasteErrMsg:
 		m.Err = msg
 	}
 
-	var cmds []tea.Cmd
+	cmds := []tea.Cmd{peekCmd}
+
+	if hasBefore && !slices.Equal(before.value, m.value) {
+		cmds = append(cmds, m.scheduleValidation())
+	}
+	if hasBefore && !restored {
+		m.recordEdit(before, typed)
+	}
+
 	var cmd tea.Cmd
 
 	if m.useVirtualCursor {
@@ -682,6 +945,32 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 // View renders the textinput in its current state.
 func (m Model) View() string {
+	v := m.inputView()
+	if line, ok := m.messageView(); ok {
+		v += "\n" + line
+	}
+	return v
+}
+
+// messageView renders the error or hint line shown beneath the input, if
+// any.
+func (m Model) messageView() (string, bool) {
+	styles := m.activeStyle()
+	switch {
+	case m.ShowErrors && m.Err != nil:
+		return styles.Error.Render(m.Err.Error()), true
+	case m.Hint != "":
+		return styles.Hint.Render(m.Hint), true
+	case m.ShowErrors:
+		// Reserve the line so the layout doesn't shift when an error
+		// appears.
+		return "", true
+	}
+	return "", false
+}
+
+// inputView renders the prompt and the input itself.
+func (m Model) inputView() string {
 	// Placeholder text
 	if len(m.value) == 0 && m.Placeholder != "" {
 		return m.placeholderView()
@@ -693,14 +982,14 @@ func (m Model) View() string {
 
 	value := m.value[m.offset:m.offsetRight]
 	pos := max(0, m.pos-m.offset)
-	v := styleText(m.echoTransform(string(value[:pos])))
+	v := styleText(m.echoTransformAt(value[:pos], m.offset))
 
 	if pos < len(value) { //nolint:nestif
-		char := m.echoTransform(string(value[pos]))
+		char := m.echoTransformAt(value[pos:pos+1], m.offset+pos)
 		m.virtualCursor.SetChar(char)
-		v += m.virtualCursor.View()                            // cursor and text under it
-		v += styleText(m.echoTransform(string(value[pos+1:]))) // text after cursor
-		v += m.completionView(0)                               // suggested completion
+		v += m.virtualCursor.View()                                      // cursor and text under it
+		v += styleText(m.echoTransformAt(value[pos+1:], m.offset+pos+1)) // text after cursor
+		v += m.completionView(0)                                         // suggested completion
 	} else {
 		if m.focus && m.canAcceptSuggestion() {
 			suggestion := m.matchedSuggestions[m.currentSuggestionIndex]
@@ -893,13 +1182,82 @@ func (m *Model) previousSuggestion() {
 	}
 }
 
+// validate is called whenever the value changes. Unless validating on
+// change, it leaves the current error in place.
 func (m Model) validate(v []rune) error {
+	if m.ValidateOn != ValidateOnChange {
+		return m.Err
+	}
+	return m.runValidate(v)
+}
+
+func (m Model) runValidate(v []rune) error {
 	if m.Validate != nil {
 		return m.Validate(string(v))
 	}
 	return nil
 }
 
+// Validating returns whether asynchronous validation is in progress.
+func (m Model) Validating() bool {
+	return m.validating
+}
+
+// RunValidation validates the input immediately, regardless of ValidateOn,
+// setting Err accordingly. If Validate passes and AsyncValidate is set, the
+// returned command performs asynchronous validation. This is typically called
+// when a form is submitted.
+func (m *Model) RunValidation() tea.Cmd {
+	m.dropValidation()
+	m.Err = m.runValidate(m.value)
+	if m.Err != nil {
+		return nil
+	}
+	return m.validateAsync()
+}
+
+// dropValidation discards the result of any asynchronous validation in
+// progress, such as when the value is replaced.
+func (m *Model) dropValidation() {
+	m.validationTag++
+	m.validating = false
+}
+
+// scheduleValidation is called after the user changes the value. It drops
+// any validation in progress and, when validating on change, schedules
+// asynchronous validation once the value has been stable for
+// ValidationDelay.
+func (m *Model) scheduleValidation() tea.Cmd {
+	m.dropValidation()
+	if m.ValidateOn != ValidateOnChange || m.AsyncValidate == nil || m.Err != nil {
+		return nil
+	}
+	if m.ValidationDelay <= 0 {
+		return m.validateAsync()
+	}
+	id, tag := m.id, m.validationTag
+	return tea.Tick(m.ValidationDelay, func(time.Time) tea.Msg {
+		return validationTickMsg{id: id, tag: tag}
+	})
+}
+
+// validateAsync starts asynchronous validation of the current value.
+func (m *Model) validateAsync() tea.Cmd {
+	if m.AsyncValidate == nil {
+		return nil
+	}
+	cmd := m.AsyncValidate(string(m.value))
+	if cmd == nil {
+		return nil
+	}
+	m.validating = true
+	id, tag := m.id, m.validationTag
+	return func() tea.Msg {
+		err, _ := cmd().(error)
+		return ValidationMsg{id: id, tag: tag, err: err}
+	}
+}
+
 // Cursor returns a [tea.Cursor] for rendering a real cursor in a Bubble Tea
 // program. This requires that [Model.VirtualCursor] is set to false.
 //
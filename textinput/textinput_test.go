@@ -1,10 +1,15 @@
 package textinput
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 )
@@ -118,3 +123,319 @@ func sendString(m Model, str string) Model {
 
 	return m
 }
+
+func TestPathCompleter(t *testing.T) {
+	fsys := fstest.MapFS{
+		"home/user/documents/report.txt": {},
+		"home/user/downloads/movie.mkv":  {},
+		"home/user/.config/app.toml":     {},
+		"home/user/notes.md":             {},
+	}
+	pc := PathCompleter{FS: fsys, WorkingDir: "/home/user", HomeDir: "/home/user"}
+
+	input := New()
+	input.Completer = &pc
+	input.Focus()
+
+	tab := tea.KeyPressMsg{Code: tea.KeyTab}
+	complete := func(value string) string {
+		input.SetValue(value)
+		input.CursorEnd()
+		input, _ = input.Update(tab)
+		return input.Value()
+	}
+
+	if got := complete("n"); got != "notes.md" {
+		t.Errorf("expected unique match to complete, got %q", got)
+	}
+	if got := complete("d"); got != "do" {
+		t.Errorf("expected common prefix, got %q", got)
+	}
+	if len(pc.Candidates()) != 0 {
+		t.Errorf("expected no candidates after a single completion, got %v", pc.Candidates())
+	}
+
+	input, _ = input.Update(tab)
+	if want := []string{"documents/", "downloads/"}; !slices.Equal(pc.Candidates(), want) {
+		t.Errorf("expected candidates %v on second completion, got %v", want, pc.Candidates())
+	}
+
+	if got := complete("~/doc"); got != "/home/user/documents/" {
+		t.Errorf("expected home expansion and trailing separator, got %q", got)
+	}
+	if got := complete("/home/user/documents/"); got != "/home/user/documents/report.txt" {
+		t.Errorf("expected directory contents to complete, got %q", got)
+	}
+	if got := complete("."); got != ".config/" {
+		t.Errorf("expected hidden entries when typing a dot, got %q", got)
+	}
+
+	pc.DirsOnly = true
+	if got := complete(""); got != "do" {
+		t.Errorf("expected only directories to be offered, got %q", got)
+	}
+}
+
+func TestToggleReveal(t *testing.T) {
+	input := New()
+	input.EchoMode = EchoPassword
+	input.Focus()
+	input = sendString(input, "secret")
+
+	if got := input.View(); strings.Contains(got, "secret") {
+		t.Fatalf("expected password to be masked, got %q", got)
+	}
+
+	ctrlR := tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl}
+	input, _ = input.Update(ctrlR)
+	if !input.Revealed() || input.EchoMode != EchoNormal {
+		t.Fatalf("expected password to be revealed, got echo mode %v", input.EchoMode)
+	}
+	if got := input.View(); !strings.Contains(got, "secret") {
+		t.Fatalf("expected password to be visible, got %q", got)
+	}
+
+	input, _ = input.Update(ctrlR)
+	if input.Revealed() || input.EchoMode != EchoPassword {
+		t.Fatalf("expected password to be masked again, got echo mode %v", input.EchoMode)
+	}
+
+	plain := New()
+	plain.Focus()
+	plain.ToggleReveal()
+	if plain.Revealed() || plain.EchoMode != EchoNormal {
+		t.Fatal("expected reveal to have no effect on a normal input")
+	}
+}
+
+func TestPeekLastCharacter(t *testing.T) {
+	input := New()
+	input.EchoMode = EchoPassword
+	input.PeekDuration = time.Second
+	input.Focus()
+
+	input, _ = input.Update(keyPress('a'))
+	input, cmd := input.Update(keyPress('b'))
+	if got := input.View(); !strings.Contains(got, "*b") || strings.Contains(got, "a") {
+		t.Fatalf("expected only the last character to be visible, got %q", got)
+	}
+
+	// A peek message from an earlier keystroke must be ignored.
+	stale := PeekMsg{id: input.id, tag: input.peekTag - 1}
+	input, _ = input.Update(stale)
+	if got := input.View(); !strings.Contains(got, "b") {
+		t.Fatalf("expected stale peek message to be ignored, got %q", got)
+	}
+
+	if cmd == nil {
+		t.Fatal("expected a command to mask the character")
+	}
+	input, _ = input.Update(PeekMsg{id: input.id, tag: input.peekTag})
+	if got := input.View(); strings.Contains(got, "b") {
+		t.Fatalf("expected character to be masked, got %q", got)
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	undo := tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl}
+	redo := tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl | tea.ModShift}
+
+	input := New()
+	input.Focus()
+	input = sendString(input, "hello world")
+
+	input, _ = input.Update(undo)
+	if got := input.Value(); got != "hello" {
+		t.Fatalf("expected typed word to be undone, got %q", got)
+	}
+	input, _ = input.Update(undo)
+	if got := input.Value(); got != "" {
+		t.Fatalf("expected all typing to be undone, got %q", got)
+	}
+	if input.CanUndo() {
+		t.Fatal("expected no more edits to undo")
+	}
+
+	input, _ = input.Update(redo)
+	input, _ = input.Update(redo)
+	if got := input.Value(); got != "hello world" {
+		t.Fatalf("expected typing to be redone, got %q", got)
+	}
+
+	input, _ = input.Update(tea.KeyPressMsg{Code: 'w', Mod: tea.ModCtrl})
+	if got := input.Value(); got != "hello " {
+		t.Fatalf("expected word to be deleted, got %q", got)
+	}
+	input, _ = input.Update(undo)
+	if got := input.Value(); got != "hello world" {
+		t.Fatalf("expected deletion to be undone, got %q", got)
+	}
+
+	input = sendString(input, "!")
+	if input.CanRedo() {
+		t.Fatal("expected a new edit to discard the redo history")
+	}
+
+	input.SetValue("replaced")
+	if input.CanUndo() {
+		t.Fatal("expected SetValue to clear the undo history")
+	}
+	input = sendString(input, "!")
+	input.Reset()
+	if input.CanUndo() {
+		t.Fatal("expected Reset to clear the undo history")
+	}
+}
+
+func TestUndoRestoresCursorAndOffset(t *testing.T) {
+	input := New()
+	input.SetWidth(5)
+	input.Focus()
+	input = sendString(input, "abcdefghij")
+	wantPos, wantOffset := input.Position(), input.offset
+	if wantOffset == 0 {
+		t.Fatal("expected the input to be scrolled horizontally")
+	}
+
+	input, _ = input.Update(tea.KeyPressMsg{Code: 'u', Mod: tea.ModCtrl})
+	if input.Value() != "" || input.offset != 0 {
+		t.Fatalf("expected input to be cleared, got %q at offset %d", input.Value(), input.offset)
+	}
+
+	input, _ = input.Update(tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl})
+	if got := input.Value(); got != "abcdefghij" {
+		t.Fatalf("expected deletion to be undone, got %q", got)
+	}
+	if input.Position() != wantPos || input.offset != wantOffset {
+		t.Fatalf("expected pos %d offset %d, got pos %d offset %d",
+			wantPos, wantOffset, input.Position(), input.offset)
+	}
+}
+
+func TestValidationStrategies(t *testing.T) {
+	errEmpty := errors.New("required")
+	validate := func(s string) error {
+		if s == "" {
+			return errEmpty
+		}
+		return nil
+	}
+
+	onBlur := New()
+	onBlur.Validate = validate
+	onBlur.ValidateOn = ValidateOnBlur
+	onBlur.Focus()
+	onBlur = sendString(onBlur, "a")
+	onBlur, _ = onBlur.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	if onBlur.Err != nil {
+		t.Fatalf("expected no validation while editing, got %v", onBlur.Err)
+	}
+	onBlur.Blur()
+	if !errors.Is(onBlur.Err, errEmpty) {
+		t.Fatalf("expected validation on blur, got %v", onBlur.Err)
+	}
+
+	onSubmit := New()
+	onSubmit.Validate = validate
+	onSubmit.ValidateOn = ValidateOnSubmit
+	onSubmit.Focus()
+	onSubmit.Blur()
+	if onSubmit.Err != nil {
+		t.Fatalf("expected no validation on blur, got %v", onSubmit.Err)
+	}
+	onSubmit.RunValidation()
+	if !errors.Is(onSubmit.Err, errEmpty) {
+		t.Fatalf("expected validation on submit, got %v", onSubmit.Err)
+	}
+}
+
+func TestAsyncValidation(t *testing.T) {
+	errTaken := errors.New("username taken")
+	input := New()
+	input.AsyncValidate = func(s string) tea.Cmd {
+		return func() tea.Msg {
+			if s == "bob" {
+				return errTaken
+			}
+			return nil
+		}
+	}
+	input.Focus()
+
+	var cmd tea.Cmd
+	for _, r := range "bob" {
+		input, cmd = input.Update(keyPress(r))
+	}
+	if !input.Validating() {
+		t.Fatal("expected asynchronous validation to be in progress")
+	}
+	bobResult := findMsg[ValidationMsg](cmd())
+
+	input, cmd = input.Update(keyPress('o'))
+	input, _ = input.Update(bobResult)
+	if input.Err != nil {
+		t.Fatalf("expected stale result to be dropped, got %v", input.Err)
+	}
+
+	input, _ = input.Update(findMsg[ValidationMsg](cmd()))
+	if input.Err != nil || input.Validating() {
+		t.Fatalf("expected %q to be valid, got %v", input.Value(), input.Err)
+	}
+
+	input, _ = input.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	if cmd := input.RunValidation(); cmd != nil {
+		input, _ = input.Update(cmd())
+	}
+	if !errors.Is(input.Err, errTaken) {
+		t.Fatalf("expected %q to be taken, got %v", input.Value(), input.Err)
+	}
+
+	// Replacing the value from code drops validation in progress.
+	input.Err = nil
+	cmd = input.RunValidation()
+	input.SetValue("alice")
+	input, _ = input.Update(cmd())
+	if input.Err != nil || input.Validating() {
+		t.Fatalf("expected stale result to be dropped after SetValue, got %v", input.Err)
+	}
+}
+
+func TestValidationMessageView(t *testing.T) {
+	input := New()
+	input.Hint = "3-16 characters"
+	if got := input.View(); !strings.HasSuffix(got, "\n"+input.Styles().Blurred.Hint.Render("3-16 characters")) {
+		t.Fatalf("expected hint beneath the input, got %q", got)
+	}
+
+	input.ShowErrors = true
+	input.Err = errors.New("too short")
+	if got := input.View(); !strings.Contains(got, "too short") || strings.Contains(got, "3-16") {
+		t.Fatalf("expected error to replace the hint, got %q", got)
+	}
+
+	plain := New()
+	plain.Err = errors.New("too short")
+	if got := plain.View(); strings.Contains(got, "\n") {
+		t.Fatalf("expected a single line without ShowErrors or Hint, got %q", got)
+	}
+}
+
+// findMsg runs the message through any batch and returns the first message
+// of type T.
+func findMsg[T tea.Msg](msg tea.Msg) T {
+	var zero T
+	switch msg := msg.(type) {
+	case T:
+		return msg
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			if cmd == nil {
+				continue
+			}
+			if m, ok := any(findMsg[T](cmd())).(T); ok && !reflect.ValueOf(&m).Elem().IsZero() {
+				return m
+			}
+		}
+	}
+	return zero
+}
@@ -0,0 +1,105 @@
+package textinput
+
+import (
+	"slices"
+	"unicode"
+)
+
+// maxUndo is the maximum number of edits that are remembered.
+const maxUndo = 100
+
+// snapshot is the state of the input at a point in its editing history.
+type snapshot struct {
+	value       []rune
+	pos         int
+	offset      int
+	offsetRight int
+}
+
+// snapshot captures the current value, cursor position and horizontal
+// scroll offset.
+func (m Model) snapshot() snapshot {
+	return snapshot{
+		value:       slices.Clone(m.value),
+		pos:         m.pos,
+		offset:      m.offset,
+		offsetRight: m.offsetRight,
+	}
+}
+
+// restore returns the input to a previously captured state.
+func (m *Model) restore(s snapshot) {
+	m.value = slices.Clone(s.value)
+	m.pos = clamp(s.pos, 0, len(m.value))
+	m.offset = s.offset
+	m.offsetRight = s.offsetRight
+	m.Err = m.validate(m.value)
+	m.handleOverflow()
+	m.updateSuggestions()
+}
+
+// recordEdit pushes the state prior to an edit onto the undo stack. typed
+// holds the runes inserted by the edit, if it was the result of typing.
+// Consecutive typed characters are merged so that a run of typing is undone
+// a word at a time.
+func (m *Model) recordEdit(before snapshot, typed []rune) {
+	if slices.Equal(before.value, m.value) {
+		// Cursor movement ends a run of typing.
+		if before.pos != m.pos {
+			m.coalesce = false
+		}
+		return
+	}
+
+	m.redoStack = nil
+
+	isTyping := len(typed) == 1
+	if isTyping && m.coalesce && !unicode.IsSpace(typed[0]) {
+		return
+	}
+
+	m.undoStack = append(m.undoStack, before)
+	if len(m.undoStack) > maxUndo {
+		m.undoStack = m.undoStack[1:]
+	}
+	m.coalesce = isTyping
+}
+
+// Undo reverts the most recent edit.
+func (m *Model) Undo() {
+	if len(m.undoStack) == 0 {
+		return
+	}
+	m.redoStack = append(m.redoStack, m.snapshot())
+	m.restore(m.undoStack[len(m.undoStack)-1])
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	m.coalesce = false
+}
+
+// Redo reapplies the most recently undone edit.
+func (m *Model) Redo() {
+	if len(m.redoStack) == 0 {
+		return
+	}
+	m.undoStack = append(m.undoStack, m.snapshot())
+	m.restore(m.redoStack[len(m.redoStack)-1])
+	m.redoStack = m.redoStack[:len(m.redoStack)-1]
+	m.coalesce = false
+}
+
+// CanUndo returns whether there are edits that can be undone.
+func (m Model) CanUndo() bool {
+	return len(m.undoStack) > 0
+}
+
+// CanRedo returns whether there are undone edits that can be reapplied.
+func (m Model) CanRedo() bool {
+	return len(m.redoStack) > 0
+}
+
+// ClearHistory discards the undo and redo history.
+func (m *Model) ClearHistory() {
+	m.undoStack = nil
+	m.redoStack = nil
+	m.coalesce = false
+}